@@ -0,0 +1,881 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package BridgeV2
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+)
+
+// BridgeProposal is an auto generated low-level Go binding around an user-defined struct.
+type BridgeProposal struct {
+	Status        uint8
+	YesVotes      *big.Int
+	YesVotesTotal uint8
+	ProposedBlock *big.Int
+}
+
+// BridgeV2MetaData contains all meta data concerning the BridgeV2 contract.
+var BridgeV2MetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[],\"name\":\"_domainID\",\"outputs\":[{\"internalType\":\"uint8\",\"name\":\"\",\"type\":\"uint8\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"_feeHandler\",\"outputs\":[{\"internalType\":\"contractIFeeHandler\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"name\":\"_resourceIDToHandlerAddress\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint72\",\"name\":\"\",\"type\":\"uint72\"},{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"_hasVotedOnProposal\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint8\",\"name\":\"originDomainID\",\"type\":\"uint8\"},{\"internalType\":\"uint64\",\"name\":\"depositNonce\",\"type\":\"uint64\"},{\"internalType\":\"bytes32\",\"name\":\"dataHash\",\"type\":\"bytes32\"}],\"name\":\"getProposal\",\"outputs\":[{\"components\":[{\"internalType\":\"enumBridge.ProposalStatus\",\"name\":\"_status\",\"type\":\"uint8\"},{\"internalType\":\"uint200\",\"name\":\"_yesVotes\",\"type\":\"uint200\"},{\"internalType\":\"uint8\",\"name\":\"_yesVotesTotal\",\"type\":\"uint8\"},{\"internalType\":\"uint40\",\"name\":\"_proposedBlock\",\"type\":\"uint40\"}],\"internalType\":\"structBridge.Proposal\",\"name\":\"\",\"type\":\"tuple\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"paused\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"adminPauseTransfers\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint8\",\"name\":\"destinationDomainID\",\"type\":\"uint8\"},{\"internalType\":\"bytes32\",\"name\":\"resourceID\",\"type\":\"bytes32\"},{\"internalType\":\"bytes\",\"name\":\"depositData\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"feeData\",\"type\":\"bytes\"}],\"name\":\"deposit\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint8\",\"name\":\"domainID\",\"type\":\"uint8\"},{\"internalType\":\"uint64\",\"name\":\"depositNonce\",\"type\":\"uint64\"},{\"internalType\":\"bytes32\",\"name\":\"resourceID\",\"type\":\"bytes32\"},{\"internalType\":\"bytes\",\"name\":\"data\",\"type\":\"bytes\"}],\"name\":\"voteProposal\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint8\",\"name\":\"domainID\",\"type\":\"uint8\"},{\"internalType\":\"uint64\",\"name\":\"depositNonce\",\"type\":\"uint64\"},{\"internalType\":\"bytes\",\"name\":\"data\",\"type\":\"bytes\"},{\"internalType\":\"bytes32\",\"name\":\"resourceID\",\"type\":\"bytes32\"},{\"internalType\":\"bool\",\"name\":\"revertOnFail\",\"type\":\"bool\"}],\"name\":\"executeProposal\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint8\",\"name\":\"destinationDomainID\",\"type\":\"uint8\"},{\"indexed\":false,\"internalType\":\"bytes32\",\"name\":\"resourceID\",\"type\":\"bytes32\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"depositNonce\",\"type\":\"uint64\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"user\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"data\",\"type\":\"bytes\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"handlerResponse\",\"type\":\"bytes\"}],\"name\":\"Deposit\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint8\",\"name\":\"originDomainID\",\"type\":\"uint8\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"depositNonce\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"enumBridge.ProposalStatus\",\"name\":\"status\",\"type\":\"uint8\"},{\"indexed\":false,\"internalType\":\"bytes32\",\"name\":\"dataHash\",\"type\":\"bytes32\"}],\"name\":\"ProposalEvent\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint8\",\"name\":\"originDomainID\",\"type\":\"uint8\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"depositNonce\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"enumBridge.ProposalStatus\",\"name\":\"status\",\"type\":\"uint8\"},{\"indexed\":false,\"internalType\":\"bytes32\",\"name\":\"dataHash\",\"type\":\"bytes32\"}],\"name\":\"ProposalVote\",\"type\":\"event\"}]",
+}
+
+// BridgeV2ABI is the input ABI used to generate the binding from.
+// Deprecated: Use BridgeV2MetaData.ABI instead.
+var BridgeV2ABI = BridgeV2MetaData.ABI
+
+// BridgeV2 is an auto generated Go binding around an Ethereum contract.
+type BridgeV2 struct {
+	BridgeV2Caller     // Read-only binding to the contract
+	BridgeV2Transactor // Write-only binding to the contract
+	BridgeV2Filterer   // Log filterer for contract events
+}
+
+// BridgeV2Caller is an auto generated read-only Go binding around an Ethereum contract.
+type BridgeV2Caller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// BridgeV2Transactor is an auto generated write-only Go binding around an Ethereum contract.
+type BridgeV2Transactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// BridgeV2Filterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type BridgeV2Filterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// BridgeV2Session is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type BridgeV2Session struct {
+	Contract     *BridgeV2         // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// BridgeV2CallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type BridgeV2CallerSession struct {
+	Contract *BridgeV2Caller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts   // Call options to use throughout this session
+}
+
+// BridgeV2TransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type BridgeV2TransactorSession struct {
+	Contract     *BridgeV2Transactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts   // Transaction auth options to use throughout this session
+}
+
+// BridgeV2Raw is an auto generated low-level Go binding around an Ethereum contract.
+type BridgeV2Raw struct {
+	Contract *BridgeV2 // Generic contract binding to access the raw methods on
+}
+
+// BridgeV2CallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type BridgeV2CallerRaw struct {
+	Contract *BridgeV2Caller // Generic read-only contract binding to access the raw methods on
+}
+
+// BridgeV2TransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type BridgeV2TransactorRaw struct {
+	Contract *BridgeV2Transactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewBridgeV2 creates a new instance of BridgeV2, bound to a specific deployed contract.
+func NewBridgeV2(address common.Address, backend bind.ContractBackend) (*BridgeV2, error) {
+	contract, err := bindBridgeV2(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &BridgeV2{BridgeV2Caller: BridgeV2Caller{contract: contract}, BridgeV2Transactor: BridgeV2Transactor{contract: contract}, BridgeV2Filterer: BridgeV2Filterer{contract: contract}}, nil
+}
+
+// NewBridgeV2Caller creates a new read-only instance of BridgeV2, bound to a specific deployed contract.
+func NewBridgeV2Caller(address common.Address, caller bind.ContractCaller) (*BridgeV2Caller, error) {
+	contract, err := bindBridgeV2(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &BridgeV2Caller{contract: contract}, nil
+}
+
+// NewBridgeV2Transactor creates a new write-only instance of BridgeV2, bound to a specific deployed contract.
+func NewBridgeV2Transactor(address common.Address, transactor bind.ContractTransactor) (*BridgeV2Transactor, error) {
+	contract, err := bindBridgeV2(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &BridgeV2Transactor{contract: contract}, nil
+}
+
+// NewBridgeV2Filterer creates a new log filterer instance of BridgeV2, bound to a specific deployed contract.
+func NewBridgeV2Filterer(address common.Address, filterer bind.ContractFilterer) (*BridgeV2Filterer, error) {
+	contract, err := bindBridgeV2(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &BridgeV2Filterer{contract: contract}, nil
+}
+
+// bindBridgeV2 binds a generic wrapper to an already deployed contract.
+func bindBridgeV2(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(BridgeV2ABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_BridgeV2 *BridgeV2Raw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _BridgeV2.Contract.BridgeV2Caller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_BridgeV2 *BridgeV2Raw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _BridgeV2.Contract.BridgeV2Transactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_BridgeV2 *BridgeV2Raw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _BridgeV2.Contract.BridgeV2Transactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_BridgeV2 *BridgeV2CallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _BridgeV2.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_BridgeV2 *BridgeV2TransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _BridgeV2.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_BridgeV2 *BridgeV2TransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _BridgeV2.Contract.contract.Transact(opts, method, params...)
+}
+
+// DomainID is a free data retrieval call binding the contract method 0x9dd694f4.
+//
+// Solidity: function _domainID() view returns(uint8)
+func (_BridgeV2 *BridgeV2Caller) DomainID(opts *bind.CallOpts) (uint8, error) {
+	var out []interface{}
+	err := _BridgeV2.contract.Call(opts, &out, "_domainID")
+
+	if err != nil {
+		return *new(uint8), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint8)).(*uint8)
+
+	return out0, err
+
+}
+
+// DomainID is a free data retrieval call binding the contract method 0x9dd694f4.
+//
+// Solidity: function _domainID() view returns(uint8)
+func (_BridgeV2 *BridgeV2Session) DomainID() (uint8, error) {
+	return _BridgeV2.Contract.DomainID(&_BridgeV2.CallOpts)
+}
+
+// DomainID is a free data retrieval call binding the contract method 0x9dd694f4.
+//
+// Solidity: function _domainID() view returns(uint8)
+func (_BridgeV2 *BridgeV2CallerSession) DomainID() (uint8, error) {
+	return _BridgeV2.Contract.DomainID(&_BridgeV2.CallOpts)
+}
+
+// FeeHandler is a free data retrieval call binding the contract method 0xfe4648f4.
+//
+// Solidity: function _feeHandler() view returns(address)
+func (_BridgeV2 *BridgeV2Caller) FeeHandler(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _BridgeV2.contract.Call(opts, &out, "_feeHandler")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// FeeHandler is a free data retrieval call binding the contract method 0xfe4648f4.
+//
+// Solidity: function _feeHandler() view returns(address)
+func (_BridgeV2 *BridgeV2Session) FeeHandler() (common.Address, error) {
+	return _BridgeV2.Contract.FeeHandler(&_BridgeV2.CallOpts)
+}
+
+// FeeHandler is a free data retrieval call binding the contract method 0xfe4648f4.
+//
+// Solidity: function _feeHandler() view returns(address)
+func (_BridgeV2 *BridgeV2CallerSession) FeeHandler() (common.Address, error) {
+	return _BridgeV2.Contract.FeeHandler(&_BridgeV2.CallOpts)
+}
+
+// HasVotedOnProposal is a free data retrieval call binding the contract method 0x7febe63f.
+//
+// Solidity: function _hasVotedOnProposal(uint72 , bytes32 , address ) view returns(bool)
+func (_BridgeV2 *BridgeV2Caller) HasVotedOnProposal(opts *bind.CallOpts, arg0 *big.Int, arg1 [32]byte, arg2 common.Address) (bool, error) {
+	var out []interface{}
+	err := _BridgeV2.contract.Call(opts, &out, "_hasVotedOnProposal", arg0, arg1, arg2)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// HasVotedOnProposal is a free data retrieval call binding the contract method 0x7febe63f.
+//
+// Solidity: function _hasVotedOnProposal(uint72 , bytes32 , address ) view returns(bool)
+func (_BridgeV2 *BridgeV2Session) HasVotedOnProposal(arg0 *big.Int, arg1 [32]byte, arg2 common.Address) (bool, error) {
+	return _BridgeV2.Contract.HasVotedOnProposal(&_BridgeV2.CallOpts, arg0, arg1, arg2)
+}
+
+// HasVotedOnProposal is a free data retrieval call binding the contract method 0x7febe63f.
+//
+// Solidity: function _hasVotedOnProposal(uint72 , bytes32 , address ) view returns(bool)
+func (_BridgeV2 *BridgeV2CallerSession) HasVotedOnProposal(arg0 *big.Int, arg1 [32]byte, arg2 common.Address) (bool, error) {
+	return _BridgeV2.Contract.HasVotedOnProposal(&_BridgeV2.CallOpts, arg0, arg1, arg2)
+}
+
+// ResourceIDToHandlerAddress is a free data retrieval call binding the contract method 0x84db809f.
+//
+// Solidity: function _resourceIDToHandlerAddress(bytes32 ) view returns(address)
+func (_BridgeV2 *BridgeV2Caller) ResourceIDToHandlerAddress(opts *bind.CallOpts, arg0 [32]byte) (common.Address, error) {
+	var out []interface{}
+	err := _BridgeV2.contract.Call(opts, &out, "_resourceIDToHandlerAddress", arg0)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// ResourceIDToHandlerAddress is a free data retrieval call binding the contract method 0x84db809f.
+//
+// Solidity: function _resourceIDToHandlerAddress(bytes32 ) view returns(address)
+func (_BridgeV2 *BridgeV2Session) ResourceIDToHandlerAddress(arg0 [32]byte) (common.Address, error) {
+	return _BridgeV2.Contract.ResourceIDToHandlerAddress(&_BridgeV2.CallOpts, arg0)
+}
+
+// ResourceIDToHandlerAddress is a free data retrieval call binding the contract method 0x84db809f.
+//
+// Solidity: function _resourceIDToHandlerAddress(bytes32 ) view returns(address)
+func (_BridgeV2 *BridgeV2CallerSession) ResourceIDToHandlerAddress(arg0 [32]byte) (common.Address, error) {
+	return _BridgeV2.Contract.ResourceIDToHandlerAddress(&_BridgeV2.CallOpts, arg0)
+}
+
+// GetProposal is a free data retrieval call binding the contract method 0xa9cf69fa.
+//
+// Solidity: function getProposal(uint8 originDomainID, uint64 depositNonce, bytes32 dataHash) view returns((uint8,uint200,uint8,uint40))
+func (_BridgeV2 *BridgeV2Caller) GetProposal(opts *bind.CallOpts, originDomainID uint8, depositNonce uint64, dataHash [32]byte) (BridgeProposal, error) {
+	var out []interface{}
+	err := _BridgeV2.contract.Call(opts, &out, "getProposal", originDomainID, depositNonce, dataHash)
+
+	if err != nil {
+		return *new(BridgeProposal), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(BridgeProposal)).(*BridgeProposal)
+
+	return out0, err
+
+}
+
+// GetProposal is a free data retrieval call binding the contract method 0xa9cf69fa.
+//
+// Solidity: function getProposal(uint8 originDomainID, uint64 depositNonce, bytes32 dataHash) view returns((uint8,uint200,uint8,uint40))
+func (_BridgeV2 *BridgeV2Session) GetProposal(originDomainID uint8, depositNonce uint64, dataHash [32]byte) (BridgeProposal, error) {
+	return _BridgeV2.Contract.GetProposal(&_BridgeV2.CallOpts, originDomainID, depositNonce, dataHash)
+}
+
+// GetProposal is a free data retrieval call binding the contract method 0xa9cf69fa.
+//
+// Solidity: function getProposal(uint8 originDomainID, uint64 depositNonce, bytes32 dataHash) view returns((uint8,uint200,uint8,uint40))
+func (_BridgeV2 *BridgeV2CallerSession) GetProposal(originDomainID uint8, depositNonce uint64, dataHash [32]byte) (BridgeProposal, error) {
+	return _BridgeV2.Contract.GetProposal(&_BridgeV2.CallOpts, originDomainID, depositNonce, dataHash)
+}
+
+// Paused is a free data retrieval call binding the contract method 0x5c975abb.
+//
+// Solidity: function paused() view returns(bool)
+func (_BridgeV2 *BridgeV2Caller) Paused(opts *bind.CallOpts) (bool, error) {
+	var out []interface{}
+	err := _BridgeV2.contract.Call(opts, &out, "paused")
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// Paused is a free data retrieval call binding the contract method 0x5c975abb.
+//
+// Solidity: function paused() view returns(bool)
+func (_BridgeV2 *BridgeV2Session) Paused() (bool, error) {
+	return _BridgeV2.Contract.Paused(&_BridgeV2.CallOpts)
+}
+
+// Paused is a free data retrieval call binding the contract method 0x5c975abb.
+//
+// Solidity: function paused() view returns(bool)
+func (_BridgeV2 *BridgeV2CallerSession) Paused() (bool, error) {
+	return _BridgeV2.Contract.Paused(&_BridgeV2.CallOpts)
+}
+
+// AdminPauseTransfers is a paid mutator transaction binding the contract method 0x80ae1c28.
+//
+// Solidity: function adminPauseTransfers() returns()
+func (_BridgeV2 *BridgeV2Transactor) AdminPauseTransfers(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _BridgeV2.contract.Transact(opts, "adminPauseTransfers")
+}
+
+// AdminPauseTransfers is a paid mutator transaction binding the contract method 0x80ae1c28.
+//
+// Solidity: function adminPauseTransfers() returns()
+func (_BridgeV2 *BridgeV2Session) AdminPauseTransfers() (*types.Transaction, error) {
+	return _BridgeV2.Contract.AdminPauseTransfers(&_BridgeV2.TransactOpts)
+}
+
+// AdminPauseTransfers is a paid mutator transaction binding the contract method 0x80ae1c28.
+//
+// Solidity: function adminPauseTransfers() returns()
+func (_BridgeV2 *BridgeV2TransactorSession) AdminPauseTransfers() (*types.Transaction, error) {
+	return _BridgeV2.Contract.AdminPauseTransfers(&_BridgeV2.TransactOpts)
+}
+
+// Deposit is a paid mutator transaction binding the contract method 0x73c45c98.
+//
+// Solidity: function deposit(uint8 destinationDomainID, bytes32 resourceID, bytes depositData, bytes feeData) payable returns()
+func (_BridgeV2 *BridgeV2Transactor) Deposit(opts *bind.TransactOpts, destinationDomainID uint8, resourceID [32]byte, depositData []byte, feeData []byte) (*types.Transaction, error) {
+	return _BridgeV2.contract.Transact(opts, "deposit", destinationDomainID, resourceID, depositData, feeData)
+}
+
+// Deposit is a paid mutator transaction binding the contract method 0x73c45c98.
+//
+// Solidity: function deposit(uint8 destinationDomainID, bytes32 resourceID, bytes depositData, bytes feeData) payable returns()
+func (_BridgeV2 *BridgeV2Session) Deposit(destinationDomainID uint8, resourceID [32]byte, depositData []byte, feeData []byte) (*types.Transaction, error) {
+	return _BridgeV2.Contract.Deposit(&_BridgeV2.TransactOpts, destinationDomainID, resourceID, depositData, feeData)
+}
+
+// Deposit is a paid mutator transaction binding the contract method 0x73c45c98.
+//
+// Solidity: function deposit(uint8 destinationDomainID, bytes32 resourceID, bytes depositData, bytes feeData) payable returns()
+func (_BridgeV2 *BridgeV2TransactorSession) Deposit(destinationDomainID uint8, resourceID [32]byte, depositData []byte, feeData []byte) (*types.Transaction, error) {
+	return _BridgeV2.Contract.Deposit(&_BridgeV2.TransactOpts, destinationDomainID, resourceID, depositData, feeData)
+}
+
+// ExecuteProposal is a paid mutator transaction binding the contract method 0x206a98fd.
+//
+// Solidity: function executeProposal(uint8 domainID, uint64 depositNonce, bytes data, bytes32 resourceID, bool revertOnFail) returns()
+func (_BridgeV2 *BridgeV2Transactor) ExecuteProposal(opts *bind.TransactOpts, domainID uint8, depositNonce uint64, data []byte, resourceID [32]byte, revertOnFail bool) (*types.Transaction, error) {
+	return _BridgeV2.contract.Transact(opts, "executeProposal", domainID, depositNonce, data, resourceID, revertOnFail)
+}
+
+// ExecuteProposal is a paid mutator transaction binding the contract method 0x206a98fd.
+//
+// Solidity: function executeProposal(uint8 domainID, uint64 depositNonce, bytes data, bytes32 resourceID, bool revertOnFail) returns()
+func (_BridgeV2 *BridgeV2Session) ExecuteProposal(domainID uint8, depositNonce uint64, data []byte, resourceID [32]byte, revertOnFail bool) (*types.Transaction, error) {
+	return _BridgeV2.Contract.ExecuteProposal(&_BridgeV2.TransactOpts, domainID, depositNonce, data, resourceID, revertOnFail)
+}
+
+// ExecuteProposal is a paid mutator transaction binding the contract method 0x206a98fd.
+//
+// Solidity: function executeProposal(uint8 domainID, uint64 depositNonce, bytes data, bytes32 resourceID, bool revertOnFail) returns()
+func (_BridgeV2 *BridgeV2TransactorSession) ExecuteProposal(domainID uint8, depositNonce uint64, data []byte, resourceID [32]byte, revertOnFail bool) (*types.Transaction, error) {
+	return _BridgeV2.Contract.ExecuteProposal(&_BridgeV2.TransactOpts, domainID, depositNonce, data, resourceID, revertOnFail)
+}
+
+// VoteProposal is a paid mutator transaction binding the contract method 0xc0331b3e.
+//
+// Solidity: function voteProposal(uint8 domainID, uint64 depositNonce, bytes32 resourceID, bytes data) returns()
+func (_BridgeV2 *BridgeV2Transactor) VoteProposal(opts *bind.TransactOpts, domainID uint8, depositNonce uint64, resourceID [32]byte, data []byte) (*types.Transaction, error) {
+	return _BridgeV2.contract.Transact(opts, "voteProposal", domainID, depositNonce, resourceID, data)
+}
+
+// VoteProposal is a paid mutator transaction binding the contract method 0xc0331b3e.
+//
+// Solidity: function voteProposal(uint8 domainID, uint64 depositNonce, bytes32 resourceID, bytes data) returns()
+func (_BridgeV2 *BridgeV2Session) VoteProposal(domainID uint8, depositNonce uint64, resourceID [32]byte, data []byte) (*types.Transaction, error) {
+	return _BridgeV2.Contract.VoteProposal(&_BridgeV2.TransactOpts, domainID, depositNonce, resourceID, data)
+}
+
+// VoteProposal is a paid mutator transaction binding the contract method 0xc0331b3e.
+//
+// Solidity: function voteProposal(uint8 domainID, uint64 depositNonce, bytes32 resourceID, bytes data) returns()
+func (_BridgeV2 *BridgeV2TransactorSession) VoteProposal(domainID uint8, depositNonce uint64, resourceID [32]byte, data []byte) (*types.Transaction, error) {
+	return _BridgeV2.Contract.VoteProposal(&_BridgeV2.TransactOpts, domainID, depositNonce, resourceID, data)
+}
+
+// BridgeV2DepositIterator is returned from FilterDeposit and is used to iterate over the raw logs and unpacked data for Deposit events raised by the BridgeV2 contract.
+type BridgeV2DepositIterator struct {
+	Event *BridgeV2Deposit // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *BridgeV2DepositIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(BridgeV2Deposit)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(BridgeV2Deposit)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *BridgeV2DepositIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *BridgeV2DepositIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// BridgeV2Deposit represents a Deposit event raised by the BridgeV2 contract.
+type BridgeV2Deposit struct {
+	DestinationDomainID uint8
+	ResourceID          [32]byte
+	DepositNonce        uint64
+	User                common.Address
+	Data                []byte
+	HandlerResponse     []byte
+	Raw                 types.Log // Blockchain specific contextual infos
+}
+
+// FilterDeposit is a free log retrieval operation binding the contract event 0x17bc3181e17a9620a479c24e6c606e474ba84fc036877b768926872e8cd0e11f.
+//
+// Solidity: event Deposit(uint8 destinationDomainID, bytes32 resourceID, uint64 depositNonce, address indexed user, bytes data, bytes handlerResponse)
+func (_BridgeV2 *BridgeV2Filterer) FilterDeposit(opts *bind.FilterOpts, user []common.Address) (*BridgeV2DepositIterator, error) {
+
+	var userRule []interface{}
+	for _, userItem := range user {
+		userRule = append(userRule, userItem)
+	}
+
+	logs, sub, err := _BridgeV2.contract.FilterLogs(opts, "Deposit", userRule)
+	if err != nil {
+		return nil, err
+	}
+	return &BridgeV2DepositIterator{contract: _BridgeV2.contract, event: "Deposit", logs: logs, sub: sub}, nil
+}
+
+// WatchDeposit is a free log subscription operation binding the contract event 0x17bc3181e17a9620a479c24e6c606e474ba84fc036877b768926872e8cd0e11f.
+//
+// Solidity: event Deposit(uint8 destinationDomainID, bytes32 resourceID, uint64 depositNonce, address indexed user, bytes data, bytes handlerResponse)
+func (_BridgeV2 *BridgeV2Filterer) WatchDeposit(opts *bind.WatchOpts, sink chan<- *BridgeV2Deposit, user []common.Address) (event.Subscription, error) {
+
+	var userRule []interface{}
+	for _, userItem := range user {
+		userRule = append(userRule, userItem)
+	}
+
+	logs, sub, err := _BridgeV2.contract.WatchLogs(opts, "Deposit", userRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(BridgeV2Deposit)
+				if err := _BridgeV2.contract.UnpackLog(event, "Deposit", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseDeposit is a log parse operation binding the contract event 0x17bc3181e17a9620a479c24e6c606e474ba84fc036877b768926872e8cd0e11f.
+//
+// Solidity: event Deposit(uint8 destinationDomainID, bytes32 resourceID, uint64 depositNonce, address indexed user, bytes data, bytes handlerResponse)
+func (_BridgeV2 *BridgeV2Filterer) ParseDeposit(log types.Log) (*BridgeV2Deposit, error) {
+	event := new(BridgeV2Deposit)
+	if err := _BridgeV2.contract.UnpackLog(event, "Deposit", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// BridgeV2ProposalEventIterator is returned from FilterProposalEvent and is used to iterate over the raw logs and unpacked data for ProposalEvent events raised by the BridgeV2 contract.
+type BridgeV2ProposalEventIterator struct {
+	Event *BridgeV2ProposalEvent // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *BridgeV2ProposalEventIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(BridgeV2ProposalEvent)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(BridgeV2ProposalEvent)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *BridgeV2ProposalEventIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *BridgeV2ProposalEventIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// BridgeV2ProposalEvent represents a ProposalEvent event raised by the BridgeV2 contract.
+type BridgeV2ProposalEvent struct {
+	OriginDomainID uint8
+	DepositNonce   uint64
+	Status         uint8
+	DataHash       [32]byte
+	Raw            types.Log // Blockchain specific contextual infos
+}
+
+// FilterProposalEvent is a free log retrieval operation binding the contract event 0x968626a768e76ba1363efe44e322a6c4900c5f084e0b45f35e294dfddaa9e0d5.
+//
+// Solidity: event ProposalEvent(uint8 originDomainID, uint64 depositNonce, uint8 status, bytes32 dataHash)
+func (_BridgeV2 *BridgeV2Filterer) FilterProposalEvent(opts *bind.FilterOpts) (*BridgeV2ProposalEventIterator, error) {
+
+	logs, sub, err := _BridgeV2.contract.FilterLogs(opts, "ProposalEvent")
+	if err != nil {
+		return nil, err
+	}
+	return &BridgeV2ProposalEventIterator{contract: _BridgeV2.contract, event: "ProposalEvent", logs: logs, sub: sub}, nil
+}
+
+// WatchProposalEvent is a free log subscription operation binding the contract event 0x968626a768e76ba1363efe44e322a6c4900c5f084e0b45f35e294dfddaa9e0d5.
+//
+// Solidity: event ProposalEvent(uint8 originDomainID, uint64 depositNonce, uint8 status, bytes32 dataHash)
+func (_BridgeV2 *BridgeV2Filterer) WatchProposalEvent(opts *bind.WatchOpts, sink chan<- *BridgeV2ProposalEvent) (event.Subscription, error) {
+
+	logs, sub, err := _BridgeV2.contract.WatchLogs(opts, "ProposalEvent")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(BridgeV2ProposalEvent)
+				if err := _BridgeV2.contract.UnpackLog(event, "ProposalEvent", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseProposalEvent is a log parse operation binding the contract event 0x968626a768e76ba1363efe44e322a6c4900c5f084e0b45f35e294dfddaa9e0d5.
+//
+// Solidity: event ProposalEvent(uint8 originDomainID, uint64 depositNonce, uint8 status, bytes32 dataHash)
+func (_BridgeV2 *BridgeV2Filterer) ParseProposalEvent(log types.Log) (*BridgeV2ProposalEvent, error) {
+	event := new(BridgeV2ProposalEvent)
+	if err := _BridgeV2.contract.UnpackLog(event, "ProposalEvent", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// BridgeV2ProposalVoteIterator is returned from FilterProposalVote and is used to iterate over the raw logs and unpacked data for ProposalVote events raised by the BridgeV2 contract.
+type BridgeV2ProposalVoteIterator struct {
+	Event *BridgeV2ProposalVote // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *BridgeV2ProposalVoteIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(BridgeV2ProposalVote)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(BridgeV2ProposalVote)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *BridgeV2ProposalVoteIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *BridgeV2ProposalVoteIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// BridgeV2ProposalVote represents a ProposalVote event raised by the BridgeV2 contract.
+type BridgeV2ProposalVote struct {
+	OriginDomainID uint8
+	DepositNonce   uint64
+	Status         uint8
+	DataHash       [32]byte
+	Raw            types.Log // Blockchain specific contextual infos
+}
+
+// FilterProposalVote is a free log retrieval operation binding the contract event 0x25f8daaa4635a7729927ba3f5b3d59cc3320aca7c32c9db4e7ca7b9574343640.
+//
+// Solidity: event ProposalVote(uint8 originDomainID, uint64 depositNonce, uint8 status, bytes32 dataHash)
+func (_BridgeV2 *BridgeV2Filterer) FilterProposalVote(opts *bind.FilterOpts) (*BridgeV2ProposalVoteIterator, error) {
+
+	logs, sub, err := _BridgeV2.contract.FilterLogs(opts, "ProposalVote")
+	if err != nil {
+		return nil, err
+	}
+	return &BridgeV2ProposalVoteIterator{contract: _BridgeV2.contract, event: "ProposalVote", logs: logs, sub: sub}, nil
+}
+
+// WatchProposalVote is a free log subscription operation binding the contract event 0x25f8daaa4635a7729927ba3f5b3d59cc3320aca7c32c9db4e7ca7b9574343640.
+//
+// Solidity: event ProposalVote(uint8 originDomainID, uint64 depositNonce, uint8 status, bytes32 dataHash)
+func (_BridgeV2 *BridgeV2Filterer) WatchProposalVote(opts *bind.WatchOpts, sink chan<- *BridgeV2ProposalVote) (event.Subscription, error) {
+
+	logs, sub, err := _BridgeV2.contract.WatchLogs(opts, "ProposalVote")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(BridgeV2ProposalVote)
+				if err := _BridgeV2.contract.UnpackLog(event, "ProposalVote", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseProposalVote is a log parse operation binding the contract event 0x25f8daaa4635a7729927ba3f5b3d59cc3320aca7c32c9db4e7ca7b9574343640.
+//
+// Solidity: event ProposalVote(uint8 originDomainID, uint64 depositNonce, uint8 status, bytes32 dataHash)
+func (_BridgeV2 *BridgeV2Filterer) ParseProposalVote(log types.Log) (*BridgeV2ProposalVote, error) {
+	event := new(BridgeV2ProposalVote)
+	if err := _BridgeV2.contract.UnpackLog(event, "ProposalVote", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -0,0 +1,484 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package ERC1155Handler
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+)
+
+// ERC1155HandlerDepositRecord is an auto generated low-level Go binding around an user-defined struct.
+type ERC1155HandlerDepositRecord struct {
+	TokenAddress                   common.Address
+	LenDestinationRecipientAddress uint8
+	DestinationChainID             uint8
+	ResourceID                     [32]byte
+	DestinationRecipientAddress    []byte
+	Depositer                      common.Address
+	TokenIDs                       []*big.Int
+	Amounts                        []*big.Int
+	TransferData                   []byte
+}
+
+// ERC1155HandlerMetaData contains all meta data concerning the ERC1155Handler contract.
+var ERC1155HandlerMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[],\"name\":\"_bridgeAddress\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"_burnList\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"_contractWhitelist\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"name\":\"_resourceIDToTokenContractAddress\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"_tokenContractAddressToResourceID\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"contractAddress\",\"type\":\"address\"}],\"name\":\"setBurnable\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"resourceID\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"contractAddress\",\"type\":\"address\"}],\"name\":\"setResource\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"depositNonce\",\"type\":\"uint64\"},{\"internalType\":\"uint8\",\"name\":\"destId\",\"type\":\"uint8\"}],\"name\":\"getDepositRecord\",\"outputs\":[{\"components\":[{\"internalType\":\"address\",\"name\":\"_tokenAddress\",\"type\":\"address\"},{\"internalType\":\"uint8\",\"name\":\"_lenDestinationRecipientAddress\",\"type\":\"uint8\"},{\"internalType\":\"uint8\",\"name\":\"_destinationChainID\",\"type\":\"uint8\"},{\"internalType\":\"bytes32\",\"name\":\"_resourceID\",\"type\":\"bytes32\"},{\"internalType\":\"bytes\",\"name\":\"_destinationRecipientAddress\",\"type\":\"bytes\"},{\"internalType\":\"address\",\"name\":\"_depositer\",\"type\":\"address\"},{\"internalType\":\"uint256[]\",\"name\":\"_tokenIDs\",\"type\":\"uint256[]\"},{\"internalType\":\"uint256[]\",\"name\":\"_amounts\",\"type\":\"uint256[]\"},{\"internalType\":\"bytes\",\"name\":\"_transferData\",\"type\":\"bytes\"}],\"internalType\":\"structERC1155Handler.DepositRecord\",\"name\":\"\",\"type\":\"tuple\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"resourceID\",\"type\":\"bytes32\"},{\"internalType\":\"uint8\",\"name\":\"destinationChainID\",\"type\":\"uint8\"},{\"internalType\":\"uint64\",\"name\":\"depositNonce\",\"type\":\"uint64\"},{\"internalType\":\"address\",\"name\":\"depositer\",\"type\":\"address\"},{\"internalType\":\"bytes\",\"name\":\"data\",\"type\":\"bytes\"}],\"name\":\"deposit\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"resourceID\",\"type\":\"bytes32\"},{\"internalType\":\"bytes\",\"name\":\"data\",\"type\":\"bytes\"}],\"name\":\"executeProposal\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"tokenAddress\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"recipient\",\"type\":\"address\"},{\"internalType\":\"uint256[]\",\"name\":\"tokenIDs\",\"type\":\"uint256[]\"},{\"internalType\":\"uint256[]\",\"name\":\"amounts\",\"type\":\"uint256[]\"}],\"name\":\"withdraw\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
+}
+
+// ERC1155HandlerABI is the input ABI used to generate the binding from.
+// Deprecated: Use ERC1155HandlerMetaData.ABI instead.
+var ERC1155HandlerABI = ERC1155HandlerMetaData.ABI
+
+// ERC1155Handler is an auto generated Go binding around an Ethereum contract.
+type ERC1155Handler struct {
+	ERC1155HandlerCaller     // Read-only binding to the contract
+	ERC1155HandlerTransactor // Write-only binding to the contract
+	ERC1155HandlerFilterer   // Log filterer for contract events
+}
+
+// ERC1155HandlerCaller is an auto generated read-only Go binding around an Ethereum contract.
+type ERC1155HandlerCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ERC1155HandlerTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type ERC1155HandlerTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ERC1155HandlerFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type ERC1155HandlerFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ERC1155HandlerSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type ERC1155HandlerSession struct {
+	Contract     *ERC1155Handler   // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// ERC1155HandlerCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type ERC1155HandlerCallerSession struct {
+	Contract *ERC1155HandlerCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts         // Call options to use throughout this session
+}
+
+// ERC1155HandlerTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type ERC1155HandlerTransactorSession struct {
+	Contract     *ERC1155HandlerTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts         // Transaction auth options to use throughout this session
+}
+
+// ERC1155HandlerRaw is an auto generated low-level Go binding around an Ethereum contract.
+type ERC1155HandlerRaw struct {
+	Contract *ERC1155Handler // Generic contract binding to access the raw methods on
+}
+
+// ERC1155HandlerCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type ERC1155HandlerCallerRaw struct {
+	Contract *ERC1155HandlerCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// ERC1155HandlerTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type ERC1155HandlerTransactorRaw struct {
+	Contract *ERC1155HandlerTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewERC1155Handler creates a new instance of ERC1155Handler, bound to a specific deployed contract.
+func NewERC1155Handler(address common.Address, backend bind.ContractBackend) (*ERC1155Handler, error) {
+	contract, err := bindERC1155Handler(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &ERC1155Handler{ERC1155HandlerCaller: ERC1155HandlerCaller{contract: contract}, ERC1155HandlerTransactor: ERC1155HandlerTransactor{contract: contract}, ERC1155HandlerFilterer: ERC1155HandlerFilterer{contract: contract}}, nil
+}
+
+// NewERC1155HandlerCaller creates a new read-only instance of ERC1155Handler, bound to a specific deployed contract.
+func NewERC1155HandlerCaller(address common.Address, caller bind.ContractCaller) (*ERC1155HandlerCaller, error) {
+	contract, err := bindERC1155Handler(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ERC1155HandlerCaller{contract: contract}, nil
+}
+
+// NewERC1155HandlerTransactor creates a new write-only instance of ERC1155Handler, bound to a specific deployed contract.
+func NewERC1155HandlerTransactor(address common.Address, transactor bind.ContractTransactor) (*ERC1155HandlerTransactor, error) {
+	contract, err := bindERC1155Handler(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ERC1155HandlerTransactor{contract: contract}, nil
+}
+
+// NewERC1155HandlerFilterer creates a new log filterer instance of ERC1155Handler, bound to a specific deployed contract.
+func NewERC1155HandlerFilterer(address common.Address, filterer bind.ContractFilterer) (*ERC1155HandlerFilterer, error) {
+	contract, err := bindERC1155Handler(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &ERC1155HandlerFilterer{contract: contract}, nil
+}
+
+// bindERC1155Handler binds a generic wrapper to an already deployed contract.
+func bindERC1155Handler(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(ERC1155HandlerABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_ERC1155Handler *ERC1155HandlerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _ERC1155Handler.Contract.ERC1155HandlerCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_ERC1155Handler *ERC1155HandlerRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ERC1155Handler.Contract.ERC1155HandlerTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_ERC1155Handler *ERC1155HandlerRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _ERC1155Handler.Contract.ERC1155HandlerTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_ERC1155Handler *ERC1155HandlerCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _ERC1155Handler.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_ERC1155Handler *ERC1155HandlerTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ERC1155Handler.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_ERC1155Handler *ERC1155HandlerTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _ERC1155Handler.Contract.contract.Transact(opts, method, params...)
+}
+
+// BridgeAddress is a free data retrieval call binding the contract method 0x318c136e.
+//
+// Solidity: function _bridgeAddress() view returns(address)
+func (_ERC1155Handler *ERC1155HandlerCaller) BridgeAddress(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _ERC1155Handler.contract.Call(opts, &out, "_bridgeAddress")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// BridgeAddress is a free data retrieval call binding the contract method 0x318c136e.
+//
+// Solidity: function _bridgeAddress() view returns(address)
+func (_ERC1155Handler *ERC1155HandlerSession) BridgeAddress() (common.Address, error) {
+	return _ERC1155Handler.Contract.BridgeAddress(&_ERC1155Handler.CallOpts)
+}
+
+// BridgeAddress is a free data retrieval call binding the contract method 0x318c136e.
+//
+// Solidity: function _bridgeAddress() view returns(address)
+func (_ERC1155Handler *ERC1155HandlerCallerSession) BridgeAddress() (common.Address, error) {
+	return _ERC1155Handler.Contract.BridgeAddress(&_ERC1155Handler.CallOpts)
+}
+
+// BurnList is a free data retrieval call binding the contract method 0x6a70d081.
+//
+// Solidity: function _burnList(address ) view returns(bool)
+func (_ERC1155Handler *ERC1155HandlerCaller) BurnList(opts *bind.CallOpts, arg0 common.Address) (bool, error) {
+	var out []interface{}
+	err := _ERC1155Handler.contract.Call(opts, &out, "_burnList", arg0)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// BurnList is a free data retrieval call binding the contract method 0x6a70d081.
+//
+// Solidity: function _burnList(address ) view returns(bool)
+func (_ERC1155Handler *ERC1155HandlerSession) BurnList(arg0 common.Address) (bool, error) {
+	return _ERC1155Handler.Contract.BurnList(&_ERC1155Handler.CallOpts, arg0)
+}
+
+// BurnList is a free data retrieval call binding the contract method 0x6a70d081.
+//
+// Solidity: function _burnList(address ) view returns(bool)
+func (_ERC1155Handler *ERC1155HandlerCallerSession) BurnList(arg0 common.Address) (bool, error) {
+	return _ERC1155Handler.Contract.BurnList(&_ERC1155Handler.CallOpts, arg0)
+}
+
+// ContractWhitelist is a free data retrieval call binding the contract method 0x7f79bea8.
+//
+// Solidity: function _contractWhitelist(address ) view returns(bool)
+func (_ERC1155Handler *ERC1155HandlerCaller) ContractWhitelist(opts *bind.CallOpts, arg0 common.Address) (bool, error) {
+	var out []interface{}
+	err := _ERC1155Handler.contract.Call(opts, &out, "_contractWhitelist", arg0)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// ContractWhitelist is a free data retrieval call binding the contract method 0x7f79bea8.
+//
+// Solidity: function _contractWhitelist(address ) view returns(bool)
+func (_ERC1155Handler *ERC1155HandlerSession) ContractWhitelist(arg0 common.Address) (bool, error) {
+	return _ERC1155Handler.Contract.ContractWhitelist(&_ERC1155Handler.CallOpts, arg0)
+}
+
+// ContractWhitelist is a free data retrieval call binding the contract method 0x7f79bea8.
+//
+// Solidity: function _contractWhitelist(address ) view returns(bool)
+func (_ERC1155Handler *ERC1155HandlerCallerSession) ContractWhitelist(arg0 common.Address) (bool, error) {
+	return _ERC1155Handler.Contract.ContractWhitelist(&_ERC1155Handler.CallOpts, arg0)
+}
+
+// ResourceIDToTokenContractAddress is a free data retrieval call binding the contract method 0x0a6d55d8.
+//
+// Solidity: function _resourceIDToTokenContractAddress(bytes32 ) view returns(address)
+func (_ERC1155Handler *ERC1155HandlerCaller) ResourceIDToTokenContractAddress(opts *bind.CallOpts, arg0 [32]byte) (common.Address, error) {
+	var out []interface{}
+	err := _ERC1155Handler.contract.Call(opts, &out, "_resourceIDToTokenContractAddress", arg0)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// ResourceIDToTokenContractAddress is a free data retrieval call binding the contract method 0x0a6d55d8.
+//
+// Solidity: function _resourceIDToTokenContractAddress(bytes32 ) view returns(address)
+func (_ERC1155Handler *ERC1155HandlerSession) ResourceIDToTokenContractAddress(arg0 [32]byte) (common.Address, error) {
+	return _ERC1155Handler.Contract.ResourceIDToTokenContractAddress(&_ERC1155Handler.CallOpts, arg0)
+}
+
+// ResourceIDToTokenContractAddress is a free data retrieval call binding the contract method 0x0a6d55d8.
+//
+// Solidity: function _resourceIDToTokenContractAddress(bytes32 ) view returns(address)
+func (_ERC1155Handler *ERC1155HandlerCallerSession) ResourceIDToTokenContractAddress(arg0 [32]byte) (common.Address, error) {
+	return _ERC1155Handler.Contract.ResourceIDToTokenContractAddress(&_ERC1155Handler.CallOpts, arg0)
+}
+
+// TokenContractAddressToResourceID is a free data retrieval call binding the contract method 0xc8ba6c87.
+//
+// Solidity: function _tokenContractAddressToResourceID(address ) view returns(bytes32)
+func (_ERC1155Handler *ERC1155HandlerCaller) TokenContractAddressToResourceID(opts *bind.CallOpts, arg0 common.Address) ([32]byte, error) {
+	var out []interface{}
+	err := _ERC1155Handler.contract.Call(opts, &out, "_tokenContractAddressToResourceID", arg0)
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// TokenContractAddressToResourceID is a free data retrieval call binding the contract method 0xc8ba6c87.
+//
+// Solidity: function _tokenContractAddressToResourceID(address ) view returns(bytes32)
+func (_ERC1155Handler *ERC1155HandlerSession) TokenContractAddressToResourceID(arg0 common.Address) ([32]byte, error) {
+	return _ERC1155Handler.Contract.TokenContractAddressToResourceID(&_ERC1155Handler.CallOpts, arg0)
+}
+
+// TokenContractAddressToResourceID is a free data retrieval call binding the contract method 0xc8ba6c87.
+//
+// Solidity: function _tokenContractAddressToResourceID(address ) view returns(bytes32)
+func (_ERC1155Handler *ERC1155HandlerCallerSession) TokenContractAddressToResourceID(arg0 common.Address) ([32]byte, error) {
+	return _ERC1155Handler.Contract.TokenContractAddressToResourceID(&_ERC1155Handler.CallOpts, arg0)
+}
+
+// GetDepositRecord is a free data retrieval call binding the contract method 0xba484c09.
+//
+// Solidity: function getDepositRecord(uint64 depositNonce, uint8 destId) view returns((address,uint8,uint8,bytes32,bytes,address,uint256[],uint256[],bytes))
+func (_ERC1155Handler *ERC1155HandlerCaller) GetDepositRecord(opts *bind.CallOpts, depositNonce uint64, destId uint8) (ERC1155HandlerDepositRecord, error) {
+	var out []interface{}
+	err := _ERC1155Handler.contract.Call(opts, &out, "getDepositRecord", depositNonce, destId)
+
+	if err != nil {
+		return *new(ERC1155HandlerDepositRecord), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(ERC1155HandlerDepositRecord)).(*ERC1155HandlerDepositRecord)
+
+	return out0, err
+
+}
+
+// GetDepositRecord is a free data retrieval call binding the contract method 0xba484c09.
+//
+// Solidity: function getDepositRecord(uint64 depositNonce, uint8 destId) view returns((address,uint8,uint8,bytes32,bytes,address,uint256[],uint256[],bytes))
+func (_ERC1155Handler *ERC1155HandlerSession) GetDepositRecord(depositNonce uint64, destId uint8) (ERC1155HandlerDepositRecord, error) {
+	return _ERC1155Handler.Contract.GetDepositRecord(&_ERC1155Handler.CallOpts, depositNonce, destId)
+}
+
+// GetDepositRecord is a free data retrieval call binding the contract method 0xba484c09.
+//
+// Solidity: function getDepositRecord(uint64 depositNonce, uint8 destId) view returns((address,uint8,uint8,bytes32,bytes,address,uint256[],uint256[],bytes))
+func (_ERC1155Handler *ERC1155HandlerCallerSession) GetDepositRecord(depositNonce uint64, destId uint8) (ERC1155HandlerDepositRecord, error) {
+	return _ERC1155Handler.Contract.GetDepositRecord(&_ERC1155Handler.CallOpts, depositNonce, destId)
+}
+
+// Deposit is a paid mutator transaction binding the contract method 0x38995da9.
+//
+// Solidity: function deposit(bytes32 resourceID, uint8 destinationChainID, uint64 depositNonce, address depositer, bytes data) returns()
+func (_ERC1155Handler *ERC1155HandlerTransactor) Deposit(opts *bind.TransactOpts, resourceID [32]byte, destinationChainID uint8, depositNonce uint64, depositer common.Address, data []byte) (*types.Transaction, error) {
+	return _ERC1155Handler.contract.Transact(opts, "deposit", resourceID, destinationChainID, depositNonce, depositer, data)
+}
+
+// Deposit is a paid mutator transaction binding the contract method 0x38995da9.
+//
+// Solidity: function deposit(bytes32 resourceID, uint8 destinationChainID, uint64 depositNonce, address depositer, bytes data) returns()
+func (_ERC1155Handler *ERC1155HandlerSession) Deposit(resourceID [32]byte, destinationChainID uint8, depositNonce uint64, depositer common.Address, data []byte) (*types.Transaction, error) {
+	return _ERC1155Handler.Contract.Deposit(&_ERC1155Handler.TransactOpts, resourceID, destinationChainID, depositNonce, depositer, data)
+}
+
+// Deposit is a paid mutator transaction binding the contract method 0x38995da9.
+//
+// Solidity: function deposit(bytes32 resourceID, uint8 destinationChainID, uint64 depositNonce, address depositer, bytes data) returns()
+func (_ERC1155Handler *ERC1155HandlerTransactorSession) Deposit(resourceID [32]byte, destinationChainID uint8, depositNonce uint64, depositer common.Address, data []byte) (*types.Transaction, error) {
+	return _ERC1155Handler.Contract.Deposit(&_ERC1155Handler.TransactOpts, resourceID, destinationChainID, depositNonce, depositer, data)
+}
+
+// ExecuteProposal is a paid mutator transaction binding the contract method 0xe248cff2.
+//
+// Solidity: function executeProposal(bytes32 resourceID, bytes data) returns()
+func (_ERC1155Handler *ERC1155HandlerTransactor) ExecuteProposal(opts *bind.TransactOpts, resourceID [32]byte, data []byte) (*types.Transaction, error) {
+	return _ERC1155Handler.contract.Transact(opts, "executeProposal", resourceID, data)
+}
+
+// ExecuteProposal is a paid mutator transaction binding the contract method 0xe248cff2.
+//
+// Solidity: function executeProposal(bytes32 resourceID, bytes data) returns()
+func (_ERC1155Handler *ERC1155HandlerSession) ExecuteProposal(resourceID [32]byte, data []byte) (*types.Transaction, error) {
+	return _ERC1155Handler.Contract.ExecuteProposal(&_ERC1155Handler.TransactOpts, resourceID, data)
+}
+
+// ExecuteProposal is a paid mutator transaction binding the contract method 0xe248cff2.
+//
+// Solidity: function executeProposal(bytes32 resourceID, bytes data) returns()
+func (_ERC1155Handler *ERC1155HandlerTransactorSession) ExecuteProposal(resourceID [32]byte, data []byte) (*types.Transaction, error) {
+	return _ERC1155Handler.Contract.ExecuteProposal(&_ERC1155Handler.TransactOpts, resourceID, data)
+}
+
+// SetBurnable is a paid mutator transaction binding the contract method 0x07b7ed99.
+//
+// Solidity: function setBurnable(address contractAddress) returns()
+func (_ERC1155Handler *ERC1155HandlerTransactor) SetBurnable(opts *bind.TransactOpts, contractAddress common.Address) (*types.Transaction, error) {
+	return _ERC1155Handler.contract.Transact(opts, "setBurnable", contractAddress)
+}
+
+// SetBurnable is a paid mutator transaction binding the contract method 0x07b7ed99.
+//
+// Solidity: function setBurnable(address contractAddress) returns()
+func (_ERC1155Handler *ERC1155HandlerSession) SetBurnable(contractAddress common.Address) (*types.Transaction, error) {
+	return _ERC1155Handler.Contract.SetBurnable(&_ERC1155Handler.TransactOpts, contractAddress)
+}
+
+// SetBurnable is a paid mutator transaction binding the contract method 0x07b7ed99.
+//
+// Solidity: function setBurnable(address contractAddress) returns()
+func (_ERC1155Handler *ERC1155HandlerTransactorSession) SetBurnable(contractAddress common.Address) (*types.Transaction, error) {
+	return _ERC1155Handler.Contract.SetBurnable(&_ERC1155Handler.TransactOpts, contractAddress)
+}
+
+// SetResource is a paid mutator transaction binding the contract method 0xb8fa3736.
+//
+// Solidity: function setResource(bytes32 resourceID, address contractAddress) returns()
+func (_ERC1155Handler *ERC1155HandlerTransactor) SetResource(opts *bind.TransactOpts, resourceID [32]byte, contractAddress common.Address) (*types.Transaction, error) {
+	return _ERC1155Handler.contract.Transact(opts, "setResource", resourceID, contractAddress)
+}
+
+// SetResource is a paid mutator transaction binding the contract method 0xb8fa3736.
+//
+// Solidity: function setResource(bytes32 resourceID, address contractAddress) returns()
+func (_ERC1155Handler *ERC1155HandlerSession) SetResource(resourceID [32]byte, contractAddress common.Address) (*types.Transaction, error) {
+	return _ERC1155Handler.Contract.SetResource(&_ERC1155Handler.TransactOpts, resourceID, contractAddress)
+}
+
+// SetResource is a paid mutator transaction binding the contract method 0xb8fa3736.
+//
+// Solidity: function setResource(bytes32 resourceID, address contractAddress) returns()
+func (_ERC1155Handler *ERC1155HandlerTransactorSession) SetResource(resourceID [32]byte, contractAddress common.Address) (*types.Transaction, error) {
+	return _ERC1155Handler.Contract.SetResource(&_ERC1155Handler.TransactOpts, resourceID, contractAddress)
+}
+
+// Withdraw is a paid mutator transaction binding the contract method 0x44800f61.
+//
+// Solidity: function withdraw(address tokenAddress, address recipient, uint256[] tokenIDs, uint256[] amounts) returns()
+func (_ERC1155Handler *ERC1155HandlerTransactor) Withdraw(opts *bind.TransactOpts, tokenAddress common.Address, recipient common.Address, tokenIDs []*big.Int, amounts []*big.Int) (*types.Transaction, error) {
+	return _ERC1155Handler.contract.Transact(opts, "withdraw", tokenAddress, recipient, tokenIDs, amounts)
+}
+
+// Withdraw is a paid mutator transaction binding the contract method 0x44800f61.
+//
+// Solidity: function withdraw(address tokenAddress, address recipient, uint256[] tokenIDs, uint256[] amounts) returns()
+func (_ERC1155Handler *ERC1155HandlerSession) Withdraw(tokenAddress common.Address, recipient common.Address, tokenIDs []*big.Int, amounts []*big.Int) (*types.Transaction, error) {
+	return _ERC1155Handler.Contract.Withdraw(&_ERC1155Handler.TransactOpts, tokenAddress, recipient, tokenIDs, amounts)
+}
+
+// Withdraw is a paid mutator transaction binding the contract method 0x44800f61.
+//
+// Solidity: function withdraw(address tokenAddress, address recipient, uint256[] tokenIDs, uint256[] amounts) returns()
+func (_ERC1155Handler *ERC1155HandlerTransactorSession) Withdraw(tokenAddress common.Address, recipient common.Address, tokenIDs []*big.Int, amounts []*big.Int) (*types.Transaction, error) {
+	return _ERC1155Handler.Contract.Withdraw(&_ERC1155Handler.TransactOpts, tokenAddress, recipient, tokenIDs, amounts)
+}
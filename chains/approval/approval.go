@@ -0,0 +1,381 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+/*
+The approval package parks transfers above a threshold until an operator approves or rejects them. A Queue wraps the
+core.Writer of a destination chain: messages whose amount exceeds the threshold of their resource are parked instead
+of being resolved, and only passed on to the writer once approved. Approved messages stay parked until the writer
+resolved them, so they can be approved again if it fails. Rejected messages are dropped.
+
+Thresholds are configured per resource as a comma separated list of entries in the form <resourceId>:<amount>.
+Amounts are those of fungible transfers and the total amount of semi-fungible transfers, as deposited on the source
+chain.
+
+Parked messages are persisted to a file per chain/relayer pair, so they are kept across restarts. Every approval and
+rejection is appended to an audit file next to it. Operators act on the queues of a running relayer through the admin
+HTTP endpoint served by Server, for which Client is a client.
+*/
+package approval
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ChainSafe/ChainBridge/chains/payload"
+	"github.com/ChainSafe/ChainBridge/chains/store"
+	"github.com/ChainSafe/log15"
+	"github.com/centrifuge/chainbridge-utils/core"
+	"github.com/centrifuge/chainbridge-utils/msg"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const PathPostfix = ".chainbridge/approvals"
+
+// Actions recorded in the audit log
+const (
+	ActionApproved = "approved"
+	ActionRejected = "rejected"
+)
+
+var ErrNotFound = errors.New("no parked message with this ID")
+var ErrApproved = errors.New("parked message was approved and is being resolved")
+
+// Thresholds holds the amount above which transfers of each resource must be approved
+type Thresholds map[msg.ResourceId]*big.Int
+
+// ParseThresholds parses a comma separated list of entries in the form <resourceId>:<amount>
+func ParseThresholds(s string) (Thresholds, error) {
+	t := make(Thresholds)
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.Split(entry, ":")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid threshold %q: expected <resourceId>:<amount>", entry)
+		}
+		rIdBytes, err := hex.DecodeString(strings.TrimPrefix(parts[0], "0x"))
+		if err != nil || len(rIdBytes) != 32 {
+			return nil, fmt.Errorf("invalid threshold %q: invalid resource ID", entry)
+		}
+		amount, ok := new(big.Int).SetString(parts[1], 10)
+		if !ok || amount.Sign() < 0 {
+			return nil, fmt.Errorf("invalid threshold %q: invalid amount", entry)
+		}
+		rId := msg.ResourceIdFromSlice(rIdBytes)
+		if _, ok := t[rId]; ok {
+			return nil, fmt.Errorf("duplicate threshold of resource %x", rId)
+		}
+		t[rId] = amount
+	}
+	return t, nil
+}
+
+// Exceeds returns true if the message transfers more than the threshold of its resource
+func (t Thresholds) Exceeds(m msg.Message) bool {
+	threshold, ok := t[m.ResourceId]
+	if !ok {
+		return false
+	}
+	amount, err := payload.Amount(m)
+	if err != nil {
+		return false
+	}
+	return amount.Cmp(threshold) > 0
+}
+
+// Item is a parked message
+type Item struct {
+	ID       string        `json:"id"`
+	Chain    msg.ChainId   `json:"chain"` // Destination chain of the queue
+	ParkedAt time.Time     `json:"parkedAt"`
+	Amount   string        `json:"amount"`
+	Message  store.Message `json:"message"`
+}
+
+// AuditEntry is an operator action as written to the audit log
+type AuditEntry struct {
+	Time         time.Time   `json:"time"`
+	Action       string      `json:"action"`
+	Operator     string      `json:"operator"`
+	ID           string      `json:"id"`
+	Source       msg.ChainId `json:"source"`
+	Destination  msg.ChainId `json:"destination"`
+	DepositNonce msg.Nonce   `json:"depositNonce"`
+	ResourceId   string      `json:"resourceId"`
+	Amount       string      `json:"amount"`
+}
+
+// ItemID identifies the message on its destination chain
+func ItemID(m msg.Message) string {
+	return fmt.Sprintf("%d-%d", m.Source, m.DepositNonce)
+}
+
+var _ core.Writer = &Queue{}
+
+// Queue parks the messages of a destination chain that exceed the thresholds, and passes any other message on to
+// the writer.
+type Queue struct {
+	chain      msg.ChainId
+	writer     core.Writer
+	thresholds Thresholds
+	path       string // Path excluding filename
+	fullPath   string
+	auditPath  string
+	items      map[string]Item
+	approved   map[string]bool // IDs of approved messages being resolved by the writer
+	lock       sync.Mutex
+	log        log15.Logger
+	pending    prometheus.Gauge // Nil if metrics are disabled
+}
+
+// NewQueue loads the parked messages of the chain/relayer pair from disk
+func NewQueue(writer core.Writer, thresholds Thresholds, path string, chain msg.ChainId, relayer string, log log15.Logger) (*Queue, error) {
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		path = filepath.Join(home, PathPostfix)
+	}
+
+	q := &Queue{
+		chain:      chain,
+		writer:     writer,
+		thresholds: thresholds,
+		path:       path,
+		fullPath:   filepath.Join(path, fmt.Sprintf("%s-%d.approvals", relayer, chain)),
+		auditPath:  filepath.Join(path, fmt.Sprintf("%s-%d.audit", relayer, chain)),
+		items:      make(map[string]Item),
+		approved:   make(map[string]bool),
+		log:        log,
+	}
+	data, err := ioutil.ReadFile(q.fullPath)
+	if os.IsNotExist(err) {
+		return q, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var items []Item
+	if err = json.Unmarshal(data, &items); err != nil {
+		return nil, fmt.Errorf("invalid approvals file %s: %w", q.fullPath, err)
+	}
+	for _, item := range items {
+		q.items[item.ID] = item
+	}
+	return q, nil
+}
+
+// RegisterMetrics creates and registers the gauge of parked messages
+func (q *Queue) RegisterMetrics(chain string) {
+	q.pending = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: fmt.Sprintf("%s_pending_approvals", chain),
+		Help: "Number of transfers waiting for an operator's approval",
+	})
+	prometheus.MustRegister(q.pending)
+	q.pending.Set(float64(len(q.items)))
+}
+
+// Chain returns the destination chain of the queue
+func (q *Queue) Chain() msg.ChainId {
+	return q.chain
+}
+
+// ResolveMessage parks the message if it exceeds the threshold of its resource, otherwise it is resolved by the writer
+func (q *Queue) ResolveMessage(m msg.Message) bool {
+	if !q.thresholds.Exceeds(m) {
+		return q.writer.ResolveMessage(m)
+	}
+
+	encoded, err := store.EncodeMessage(m)
+	if err != nil {
+		q.log.Error("Failed to park message", "src", m.Source, "nonce", m.DepositNonce, "err", err)
+		return false
+	}
+	amount, _ := payload.Amount(m)
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	id := ItemID(m)
+	if _, ok := q.items[id]; ok {
+		q.log.Debug("Message is already waiting for approval", "id", id)
+		return true
+	}
+	item := Item{
+		ID:       id,
+		Chain:    q.chain,
+		ParkedAt: time.Now().UTC(),
+		Amount:   amount.String(),
+		Message:  encoded,
+	}
+	if err = q.write(item, ""); err != nil {
+		q.log.Error("Failed to park message", "id", id, "err", err)
+		return false
+	}
+	q.log.Warn("Transfer exceeds approval threshold, waiting for an operator", "id", id, "amount", item.Amount, "rId", m.ResourceId.Hex())
+	return true
+}
+
+// Pending returns the parked messages, oldest first
+func (q *Queue) Pending() []Item {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	items := make([]Item, 0, len(q.items))
+	for _, item := range q.items {
+		items = append(items, item)
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].ParkedAt.Before(items[j].ParkedAt) })
+	return items
+}
+
+// Approve passes the parked message on to the writer. The message is removed once the writer resolved it.
+func (q *Queue) Approve(id, operator string) error {
+	q.lock.Lock()
+	_, m, err := q.decide(id, operator, ActionApproved)
+	if err == nil {
+		q.approved[id] = true
+	}
+	q.lock.Unlock()
+	if err != nil {
+		return err
+	}
+
+	q.log.Info("Transfer approved", "id", id, "operator", operator)
+	go q.release(id, m)
+	return nil
+}
+
+// release resolves the approved message with the writer. The message is only removed if it was resolved, otherwise
+// it stays parked so it can be approved again.
+func (q *Queue) release(id string, m msg.Message) {
+	ok := q.writer.ResolveMessage(m)
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	delete(q.approved, id)
+	if !ok {
+		q.log.Error("Approved transfer failed to resolve, it remains parked", "id", id)
+		return
+	}
+	if err := q.write(Item{}, id); err != nil {
+		q.log.Error("Failed to remove resolved transfer", "id", id, "err", err)
+	}
+}
+
+// Reject drops the parked message
+func (q *Queue) Reject(id, operator string) error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if _, _, err := q.decide(id, operator, ActionRejected); err != nil {
+		return err
+	}
+	if err := q.write(Item{}, id); err != nil {
+		return err
+	}
+	q.log.Warn("Transfer rejected", "id", id, "operator", operator)
+	return nil
+}
+
+// decide records the action on the parked message in the audit log. The caller must hold the lock.
+func (q *Queue) decide(id, operator, action string) (Item, msg.Message, error) {
+	item, ok := q.items[id]
+	if !ok {
+		return Item{}, msg.Message{}, ErrNotFound
+	}
+	if q.approved[id] {
+		return Item{}, msg.Message{}, ErrApproved
+	}
+	m, err := item.Message.Decode()
+	if err != nil {
+		return Item{}, msg.Message{}, err
+	}
+	if err = q.audit(AuditEntry{
+		Time:         time.Now().UTC(),
+		Action:       action,
+		Operator:     operator,
+		ID:           id,
+		Source:       m.Source,
+		Destination:  m.Destination,
+		DepositNonce: m.DepositNonce,
+		ResourceId:   m.ResourceId.Hex(),
+		Amount:       item.Amount,
+	}); err != nil {
+		return Item{}, msg.Message{}, err
+	}
+	return item, m, nil
+}
+
+// write adds the item and removes the ID (if not empty) before replacing the file on disk. The items in memory are
+// only updated if the write succeeds. The caller must hold the lock.
+func (q *Queue) write(add Item, remove string) error {
+	items := make([]Item, 0, len(q.items)+1)
+	for id, item := range q.items {
+		if id != remove {
+			items = append(items, item)
+		}
+	}
+	if add.ID != "" {
+		items = append(items, add)
+	}
+	data, err := json.Marshal(items)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(q.path, os.ModePerm); err != nil {
+		return err
+	}
+	// Write to a temporary file first, so a crash cannot leave a partial file
+	tmp := q.fullPath + ".tmp"
+	if err = ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	if err = os.Rename(tmp, q.fullPath); err != nil {
+		return err
+	}
+
+	delete(q.items, remove)
+	if add.ID != "" {
+		q.items[add.ID] = add
+	}
+	if q.pending != nil {
+		q.pending.Set(float64(len(q.items)))
+	}
+	return nil
+}
+
+// audit appends the entry to the audit log. The caller must hold the lock.
+func (q *Queue) audit(entry AuditEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(q.path, os.ModePerm); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(q.auditPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(data, '\n'))
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,196 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package approval
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/log15"
+	"github.com/centrifuge/chainbridge-utils/msg"
+)
+
+type mockWriter struct {
+	lock     sync.Mutex
+	resolved []msg.Message
+	fail     bool
+}
+
+func (w *mockWriter) ResolveMessage(m msg.Message) bool {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.resolved = append(w.resolved, m)
+	return !w.fail
+}
+
+func (w *mockWriter) setFail(fail bool) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.fail = fail
+}
+
+func (w *mockWriter) messages() []msg.Message {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return append([]msg.Message{}, w.resolved...)
+}
+
+func waitForMessages(t *testing.T, w *mockWriter, n int) []msg.Message {
+	for i := 0; i < 100; i++ {
+		if res := w.messages(); len(res) >= n {
+			return res
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("writer did not resolve %d messages", n)
+	return nil
+}
+
+func waitForPending(t *testing.T, q *Queue, n int) []Item {
+	for i := 0; i < 100; i++ {
+		if pending := q.Pending(); len(pending) == n {
+			return pending
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("Got: %v Expected %d parked transfers", q.Pending(), n)
+	return nil
+}
+
+func TestParseThresholds(t *testing.T) {
+	rId := msg.ResourceId{31: 1}
+	thresholds, err := ParseThresholds(fmt.Sprintf("0x%x:1000", rId))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recipient := []byte{0xab}
+	if thresholds.Exceeds(msg.NewFungibleTransfer(1, 2, 1, big.NewInt(1000), rId, recipient)) {
+		t.Error("amount equal to the threshold must not need approval")
+	}
+	if !thresholds.Exceeds(msg.NewFungibleTransfer(1, 2, 1, big.NewInt(1001), rId, recipient)) {
+		t.Error("amount above the threshold must need approval")
+	}
+	if thresholds.Exceeds(msg.NewFungibleTransfer(1, 2, 1, big.NewInt(1001), msg.ResourceId{31: 2}, recipient)) {
+		t.Error("resources without a threshold must not need approval")
+	}
+
+	invalid := []string{
+		"0x01:1000",
+		fmt.Sprintf("0x%x", rId),
+		fmt.Sprintf("0x%x:-1", rId),
+		fmt.Sprintf("0x%x:1,0x%x:2", rId, rId),
+	}
+	for _, s := range invalid {
+		if _, err := ParseThresholds(s); err == nil {
+			t.Errorf("expected error for %s", s)
+		}
+	}
+}
+
+func TestQueue(t *testing.T) {
+	dir := t.TempDir()
+	rId := msg.ResourceId{31: 1}
+	thresholds := Thresholds{rId: big.NewInt(1000)}
+	small := msg.NewFungibleTransfer(1, 2, 1, big.NewInt(10), rId, []byte{0xab})
+	large := msg.NewFungibleTransfer(1, 2, 2, big.NewInt(5000), rId, []byte{0xab})
+	rejected := msg.NewFungibleTransfer(1, 2, 3, big.NewInt(5000), rId, []byte{0xab})
+
+	w := &mockWriter{}
+	q, err := NewQueue(w, thresholds, dir, 2, "relayer", log15.Root())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range []msg.Message{small, large, rejected, large} {
+		if !q.ResolveMessage(m) {
+			t.Fatalf("failed to resolve message %d", m.DepositNonce)
+		}
+	}
+	if res := w.messages(); len(res) != 1 || res[0].DepositNonce != small.DepositNonce {
+		t.Fatalf("Got: %v Expected only the small transfer to be resolved", res)
+	}
+
+	// Parked messages are loaded again after a restart
+	q, err = NewQueue(w, thresholds, dir, 2, "relayer", log15.Root())
+	if err != nil {
+		t.Fatal(err)
+	}
+	pending := q.Pending()
+	if len(pending) != 2 || pending[0].ID != ItemID(large) || pending[1].ID != ItemID(rejected) {
+		t.Fatalf("Got: %v Expected the large transfers to be parked", pending)
+	}
+
+	// Decisions are made through the admin endpoint
+	registry := NewRegistry()
+	registry.Register(q)
+	srv := httptest.NewServer(NewServer(registry))
+	defer srv.Close()
+	client := NewClient(srv.URL)
+
+	items, err := client.Pending()
+	if err != nil || len(items) != 2 {
+		t.Fatalf("Got: %v, %v Expected 2 parked transfers", items, err)
+	}
+	// Approved transfers the writer fails to resolve stay parked
+	w.setFail(true)
+	if err = client.Approve(2, ItemID(large), "alice"); err != nil {
+		t.Fatal(err)
+	}
+	waitForMessages(t, w, 2)
+	waitForPending(t, q, 2)
+	w.setFail(false)
+
+	if err = client.Approve(2, ItemID(large), "alice"); err != nil {
+		t.Fatal(err)
+	}
+	if err = client.Reject(2, ItemID(rejected), "bob"); err != nil {
+		t.Fatal(err)
+	}
+	if err = client.Approve(2, ItemID(rejected), "alice"); err == nil {
+		t.Fatal("expected error approving a rejected transfer")
+	}
+	if err = client.Approve(3, ItemID(large), "alice"); err == nil {
+		t.Fatal("expected error for a chain without a queue")
+	}
+	if err = q.Approve(ItemID(small), "alice"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Got: %v Expected: %v", err, ErrNotFound)
+	}
+
+	res := waitForMessages(t, w, 3)
+	if !reflect.DeepEqual(res[2], large) {
+		t.Fatalf("Got: %#v Expected: %#v", res[2], large)
+	}
+	waitForPending(t, q, 0)
+
+	f, err := os.Open(filepath.Join(dir, "relayer-2.audit"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var entries []AuditEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry AuditEntry
+		if err = json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatal(err)
+		}
+		entries = append(entries, entry)
+	}
+	if len(entries) != 3 ||
+		entries[0].Action != ActionApproved || entries[0].Operator != "alice" || entries[0].DepositNonce != large.DepositNonce ||
+		entries[1].Action != ActionApproved || entries[1].DepositNonce != large.DepositNonce ||
+		entries[2].Action != ActionRejected || entries[2].Operator != "bob" || entries[2].Amount != "5000" {
+		t.Fatalf("unexpected audit log: %+v", entries)
+	}
+}
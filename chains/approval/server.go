@@ -0,0 +1,200 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package approval
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/centrifuge/chainbridge-utils/msg"
+)
+
+// Registry holds the queues of all chains of the relayer
+type Registry struct {
+	queues map[msg.ChainId]*Queue
+	lock   sync.RWMutex
+}
+
+func NewRegistry() *Registry {
+	return &Registry{queues: make(map[msg.ChainId]*Queue)}
+}
+
+// DefaultRegistry holds the queues registered with Register
+var DefaultRegistry = NewRegistry()
+
+// Register adds the queue to the DefaultRegistry
+func Register(q *Queue) {
+	DefaultRegistry.Register(q)
+}
+
+// Register adds the queue, replacing any queue of the same chain
+func (r *Registry) Register(q *Queue) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.queues[q.Chain()] = q
+}
+
+// Queue returns the queue of the chain, nil if there is none
+func (r *Registry) Queue(chain msg.ChainId) *Queue {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.queues[chain]
+}
+
+// Pending returns the parked messages of all queues
+func (r *Registry) Pending() []Item {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	var items []Item
+	for _, q := range r.queues {
+		items = append(items, q.Pending()...)
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Chain < items[j].Chain })
+	return items
+}
+
+// Decision is the body of approve and reject requests
+type Decision struct {
+	Operator string `json:"operator"`
+}
+
+// Server serves the admin endpoint:
+//
+//	GET  /approvals                          lists the parked messages of all chains
+//	POST /approvals/<chain>/<id>/approve     approves a parked message
+//	POST /approvals/<chain>/<id>/reject      rejects a parked message
+//
+// Approve and reject requests take a Decision as JSON body, naming the operator in the audit log.
+type Server struct {
+	registry *Registry
+}
+
+func NewServer(r *Registry) *Server {
+	return &Server{registry: r}
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	parts := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	if parts[0] != "approvals" {
+		http.NotFound(w, req)
+		return
+	}
+
+	if len(parts) == 1 {
+		if req.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		items := s.registry.Pending()
+		if items == nil {
+			items = []Item{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(items)
+		return
+	}
+
+	if len(parts) != 4 || (parts[3] != "approve" && parts[3] != "reject") {
+		http.NotFound(w, req)
+		return
+	}
+	if req.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	chain, err := strconv.ParseUint(parts[1], 10, 8)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid chain ID %s", parts[1]), http.StatusBadRequest)
+		return
+	}
+	q := s.registry.Queue(msg.ChainId(chain))
+	if q == nil {
+		http.Error(w, fmt.Sprintf("no approval queue for chain %d", chain), http.StatusNotFound)
+		return
+	}
+	var d Decision
+	if err = json.NewDecoder(req.Body).Decode(&d); err != nil || d.Operator == "" {
+		http.Error(w, "an operator is required", http.StatusBadRequest)
+		return
+	}
+
+	if parts[3] == "approve" {
+		err = q.Approve(parts[2], d.Operator)
+	} else {
+		err = q.Reject(parts[2], d.Operator)
+	}
+	if errors.Is(err, ErrNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+	} else if errors.Is(err, ErrApproved) {
+		http.Error(w, err.Error(), http.StatusConflict)
+	} else if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	} else {
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// Client acts on the queues of a running relayer through its admin endpoint
+type Client struct {
+	url string
+}
+
+// NewClient creates a client of the admin endpoint at the url (eg. http://127.0.0.1:8002)
+func NewClient(url string) *Client {
+	return &Client{url: strings.TrimSuffix(url, "/")}
+}
+
+// Pending returns the parked messages of all chains
+func (c *Client) Pending() ([]Item, error) {
+	res, err := http.Get(c.url + "/approvals")
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, responseError(res)
+	}
+	var items []Item
+	return items, json.NewDecoder(res.Body).Decode(&items)
+}
+
+// Approve approves the parked message of the chain
+func (c *Client) Approve(chain msg.ChainId, id, operator string) error {
+	return c.decide(chain, id, "approve", operator)
+}
+
+// Reject rejects the parked message of the chain
+func (c *Client) Reject(chain msg.ChainId, id, operator string) error {
+	return c.decide(chain, id, "reject", operator)
+}
+
+func (c *Client) decide(chain msg.ChainId, id, action, operator string) error {
+	body, err := json.Marshal(Decision{Operator: operator})
+	if err != nil {
+		return err
+	}
+	res, err := http.Post(fmt.Sprintf("%s/approvals/%d/%s/%s", c.url, chain, id, action), "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusNoContent {
+		return responseError(res)
+	}
+	return nil
+}
+
+func responseError(res *http.Response) error {
+	body, _ := ioutil.ReadAll(res.Body)
+	return fmt.Errorf("%s: %s", res.Status, strings.TrimSpace(string(body)))
+}
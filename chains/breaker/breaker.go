@@ -0,0 +1,423 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+/*
+The breaker package halts the routes to a destination chain once the volume transferred over them exceeds a limit.
+A Breaker wraps the core.Writer of a destination chain and tracks the amounts of the transfers it passes on, per
+source chain and resource ID, over a rolling window.
+
+Limits are configured as a comma separated list of entries in the form
+[<sourceChainId>/]<resourceId>:<amount>:<window>, where the window is a duration (eg. 24h). An entry with a source
+chain ID only applies to transfers from that chain, and takes precedence over an entry without one. An entry without
+one limits the transfers from each source chain separately. Amounts are those of fungible transfers and the total
+amount of semi-fungible transfers, as deposited on the source chain.
+
+A transfer that would exceed the limit halts its route (source chain to destination chain), and is held along with
+any further messages of the route, so nothing is relayed until an operator resumes the route. Resuming releases the
+held messages, unless they are dropped, and resets the volume of the route. The state of the breaker is persisted to
+a file per chain/relayer pair, so halted routes stay halted across restarts.
+*/
+package breaker
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ChainSafe/ChainBridge/chains/payload"
+	"github.com/ChainSafe/ChainBridge/chains/store"
+	"github.com/ChainSafe/log15"
+	"github.com/centrifuge/chainbridge-utils/core"
+	"github.com/centrifuge/chainbridge-utils/msg"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const PathPostfix = ".chainbridge/breaker"
+
+var ErrNotHalted = errors.New("route is not halted")
+
+// Limit bounds the volume of a route over a rolling window
+type Limit struct {
+	Amount *big.Int
+	Window time.Duration
+}
+
+type routeResource struct {
+	source msg.ChainId
+	rId    msg.ResourceId
+}
+
+// Limits holds the limits of each resource, optionally per source chain
+type Limits struct {
+	all    map[msg.ResourceId]Limit
+	routes map[routeResource]Limit
+}
+
+// ParseLimits parses a comma separated list of entries in the form [<sourceChainId>/]<resourceId>:<amount>:<window>
+func ParseLimits(s string) (*Limits, error) {
+	l := &Limits{
+		all:    make(map[msg.ResourceId]Limit),
+		routes: make(map[routeResource]Limit),
+	}
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.Split(entry, ":")
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("invalid limit %q: expected [<sourceChainId>/]<resourceId>:<amount>:<window>", entry)
+		}
+
+		var source *msg.ChainId
+		rIdStr := parts[0]
+		if i := strings.Index(rIdStr, "/"); i >= 0 {
+			id, err := strconv.ParseUint(rIdStr[:i], 10, 8)
+			if err != nil {
+				return nil, fmt.Errorf("invalid limit %q: invalid source chain ID", entry)
+			}
+			chainId := msg.ChainId(id)
+			source = &chainId
+			rIdStr = rIdStr[i+1:]
+		}
+		rIdBytes, err := hex.DecodeString(strings.TrimPrefix(rIdStr, "0x"))
+		if err != nil || len(rIdBytes) != 32 {
+			return nil, fmt.Errorf("invalid limit %q: invalid resource ID", entry)
+		}
+		amount, ok := new(big.Int).SetString(parts[1], 10)
+		if !ok || amount.Sign() < 0 {
+			return nil, fmt.Errorf("invalid limit %q: invalid amount", entry)
+		}
+		window, err := time.ParseDuration(parts[2])
+		if err != nil || window <= 0 {
+			return nil, fmt.Errorf("invalid limit %q: invalid window", entry)
+		}
+
+		rId := msg.ResourceIdFromSlice(rIdBytes)
+		limit := Limit{Amount: amount, Window: window}
+		if source != nil {
+			key := routeResource{source: *source, rId: rId}
+			if _, ok := l.routes[key]; ok {
+				return nil, fmt.Errorf("duplicate limit of resource %x from chain %d", rId, *source)
+			}
+			l.routes[key] = limit
+		} else {
+			if _, ok := l.all[rId]; ok {
+				return nil, fmt.Errorf("duplicate limit of resource %x", rId)
+			}
+			l.all[rId] = limit
+		}
+	}
+	return l, nil
+}
+
+// Lookup returns the limit of the resource's transfers from the source chain
+func (l *Limits) Lookup(source msg.ChainId, rId msg.ResourceId) (Limit, bool) {
+	if limit, ok := l.routes[routeResource{source: source, rId: rId}]; ok {
+		return limit, true
+	}
+	limit, ok := l.all[rId]
+	return limit, ok
+}
+
+// record is a transfer counted towards the volume of its route
+type record struct {
+	Time       time.Time    `json:"time"`
+	Source     msg.ChainId  `json:"source"`
+	ResourceId string       `json:"resourceId"`
+	Nonce      msg.Nonce    `json:"nonce"`
+	Amount     *hexutil.Big `json:"amount"`
+}
+
+// Halt is a halted route
+type Halt struct {
+	Chain     msg.ChainId     `json:"chain"` // Destination chain of the breaker
+	Source    msg.ChainId     `json:"source"`
+	TrippedAt time.Time       `json:"trippedAt"`
+	Reason    string          `json:"reason"`
+	Held      []store.Message `json:"held"` // Messages of the route received since it was halted
+}
+
+type state struct {
+	Records []record `json:"records"`
+	Halted  []Halt   `json:"halted"`
+}
+
+// TripHandler is called when a route is halted
+type TripHandler func(source msg.ChainId, reason string)
+
+var _ core.Writer = &Breaker{}
+
+// Breaker tracks the volume of the routes to a destination chain, and halts routes that exceed their limits
+type Breaker struct {
+	chain    msg.ChainId
+	writer   core.Writer
+	limits   *Limits
+	onTrip   TripHandler // Nil if nothing is done besides halting
+	path     string      // Path excluding filename
+	fullPath string
+	records  []record
+	halted   map[msg.ChainId]*Halt
+	lock     sync.Mutex
+	log      log15.Logger
+	now      func() time.Time
+	gauge    prometheus.Gauge // Nil if metrics are disabled
+}
+
+// NewBreaker loads the state of the chain/relayer pair from disk
+func NewBreaker(writer core.Writer, limits *Limits, onTrip TripHandler, path string, chain msg.ChainId, relayer string, log log15.Logger) (*Breaker, error) {
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		path = filepath.Join(home, PathPostfix)
+	}
+
+	b := &Breaker{
+		chain:    chain,
+		writer:   writer,
+		limits:   limits,
+		onTrip:   onTrip,
+		path:     path,
+		fullPath: filepath.Join(path, fmt.Sprintf("%s-%d.breaker", relayer, chain)),
+		halted:   make(map[msg.ChainId]*Halt),
+		log:      log,
+		now:      time.Now,
+	}
+	data, err := ioutil.ReadFile(b.fullPath)
+	if os.IsNotExist(err) {
+		return b, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var s state
+	if err = json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("invalid breaker file %s: %w", b.fullPath, err)
+	}
+	b.records = s.Records
+	for i := range s.Halted {
+		b.halted[s.Halted[i].Source] = &s.Halted[i]
+		b.log.Crit("Route is halted by the circuit breaker, resume it to relay transfers", "source", s.Halted[i].Source, "reason", s.Halted[i].Reason)
+	}
+	return b, nil
+}
+
+// RegisterMetrics creates and registers the gauge of halted routes
+func (b *Breaker) RegisterMetrics(chain string) {
+	b.gauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: fmt.Sprintf("%s_halted_routes", chain),
+		Help: "Number of routes to the chain halted by the circuit breaker",
+	})
+	prometheus.MustRegister(b.gauge)
+	b.gauge.Set(float64(len(b.halted)))
+}
+
+// Chain returns the destination chain of the breaker
+func (b *Breaker) Chain() msg.ChainId {
+	return b.chain
+}
+
+// ResolveMessage holds the message if its route is halted or it exceeds the limit, otherwise it is resolved by the
+// writer
+func (b *Breaker) ResolveMessage(m msg.Message) bool {
+	b.lock.Lock()
+	if halt, ok := b.halted[m.Source]; ok {
+		defer b.lock.Unlock()
+		b.log.Warn("Route is halted, holding message", "src", m.Source, "nonce", m.DepositNonce)
+		return b.hold(halt, m)
+	}
+
+	if limit, ok := b.limits.Lookup(m.Source, m.ResourceId); ok && !b.recorded(m) {
+		if amount, err := payload.Amount(m); err == nil {
+			now := b.now()
+			b.prune(now)
+			volume := b.volume(m.Source, m.ResourceId, now.Add(-limit.Window))
+			volume.Add(volume, amount)
+			if volume.Cmp(limit.Amount) > 0 {
+				defer b.lock.Unlock()
+				reason := fmt.Sprintf("volume %s of resource %s exceeds %s over %s", volume, m.ResourceId.Hex(), limit.Amount, limit.Window)
+				return b.trip(m, reason)
+			}
+			b.records = append(b.records, record{
+				Time:       now,
+				Source:     m.Source,
+				ResourceId: m.ResourceId.Hex(),
+				Nonce:      m.DepositNonce,
+				Amount:     (*hexutil.Big)(amount),
+			})
+			if err = b.write(); err != nil {
+				b.log.Error("Failed to record transfer volume", "src", m.Source, "nonce", m.DepositNonce, "err", err)
+			}
+		}
+	}
+	b.lock.Unlock()
+
+	return b.writer.ResolveMessage(m)
+}
+
+// trip halts the route of the message and holds the message. The caller must hold the lock.
+func (b *Breaker) trip(m msg.Message, reason string) bool {
+	halt := &Halt{
+		Chain:     b.chain,
+		Source:    m.Source,
+		TrippedAt: b.now().UTC(),
+		Reason:    reason,
+	}
+	b.halted[m.Source] = halt
+	if b.gauge != nil {
+		b.gauge.Set(float64(len(b.halted)))
+	}
+	b.log.Crit("Circuit breaker tripped, halting route", "src", m.Source, "dest", b.chain, "nonce", m.DepositNonce, "reason", reason)
+	if b.onTrip != nil {
+		go b.onTrip(m.Source, reason)
+	}
+	return b.hold(halt, m)
+}
+
+// hold adds the message to the held messages of the halted route. The caller must hold the lock.
+func (b *Breaker) hold(halt *Halt, m msg.Message) bool {
+	encoded, err := store.EncodeMessage(m)
+	if err != nil {
+		b.log.Error("Failed to hold message", "src", m.Source, "nonce", m.DepositNonce, "err", err)
+		return false
+	}
+	for _, held := range halt.Held {
+		if held.DepositNonce == m.DepositNonce {
+			return true
+		}
+	}
+	halt.Held = append(halt.Held, encoded)
+	if err = b.write(); err != nil {
+		b.log.Error("Failed to persist held message", "src", m.Source, "nonce", m.DepositNonce, "err", err)
+	}
+	return true
+}
+
+// recorded returns true if the message was already counted. The caller must hold the lock.
+func (b *Breaker) recorded(m msg.Message) bool {
+	for _, r := range b.records {
+		if r.Source == m.Source && r.Nonce == m.DepositNonce {
+			return true
+		}
+	}
+	return false
+}
+
+// prune removes records outside the window of their limit. The caller must hold the lock.
+func (b *Breaker) prune(now time.Time) {
+	records := b.records[:0]
+	for _, r := range b.records {
+		rIdBytes, _ := hex.DecodeString(r.ResourceId)
+		limit, ok := b.limits.Lookup(r.Source, msg.ResourceIdFromSlice(rIdBytes))
+		if ok && r.Time.After(now.Add(-limit.Window)) {
+			records = append(records, r)
+		}
+	}
+	b.records = records
+}
+
+// volume sums the amounts of the resource transferred from the source since the given time. The caller must hold
+// the lock.
+func (b *Breaker) volume(source msg.ChainId, rId msg.ResourceId, since time.Time) *big.Int {
+	total := big.NewInt(0)
+	for _, r := range b.records {
+		if r.Source == source && r.ResourceId == rId.Hex() && r.Time.After(since) {
+			total.Add(total, r.Amount.ToInt())
+		}
+	}
+	return total
+}
+
+// Halted returns the halted routes
+func (b *Breaker) Halted() []Halt {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	halted := make([]Halt, 0, len(b.halted))
+	for _, halt := range b.halted {
+		halted = append(halted, *halt)
+	}
+	sort.Slice(halted, func(i, j int) bool { return halted[i].Source < halted[j].Source })
+	return halted
+}
+
+// Resume lets the route relay again and resets its volume. The held messages are passed on to the writer, unless
+// drop is set. It returns the number of held messages.
+func (b *Breaker) Resume(source msg.ChainId, operator string, drop bool) (int, error) {
+	b.lock.Lock()
+	halt, ok := b.halted[source]
+	if !ok {
+		b.lock.Unlock()
+		return 0, ErrNotHalted
+	}
+	held := make([]msg.Message, 0, len(halt.Held))
+	for _, encoded := range halt.Held {
+		m, err := encoded.Decode()
+		if err != nil {
+			b.lock.Unlock()
+			return 0, err
+		}
+		held = append(held, m)
+	}
+
+	delete(b.halted, source)
+	records := b.records[:0]
+	for _, r := range b.records {
+		if r.Source != source {
+			records = append(records, r)
+		}
+	}
+	b.records = records
+	if b.gauge != nil {
+		b.gauge.Set(float64(len(b.halted)))
+	}
+	err := b.write()
+	b.lock.Unlock()
+	if err != nil {
+		b.log.Error("Failed to persist resumed route", "src", source, "err", err)
+	}
+
+	b.log.Warn("Route resumed", "src", source, "operator", operator, "held", len(held), "dropped", drop)
+	if !drop {
+		go func() {
+			for _, m := range held {
+				b.writer.ResolveMessage(m)
+			}
+		}()
+	}
+	return len(held), nil
+}
+
+// write replaces the state on disk. The caller must hold the lock.
+func (b *Breaker) write() error {
+	s := state{Records: b.records, Halted: make([]Halt, 0, len(b.halted))}
+	for _, halt := range b.halted {
+		s.Halted = append(s.Halted, *halt)
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(b.path, os.ModePerm); err != nil {
+		return err
+	}
+	// Write to a temporary file first, so a crash cannot leave a partial file
+	tmp := b.fullPath + ".tmp"
+	if err = ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, b.fullPath)
+}
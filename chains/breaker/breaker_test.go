@@ -0,0 +1,165 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package breaker
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/log15"
+	"github.com/centrifuge/chainbridge-utils/msg"
+)
+
+type mockWriter struct {
+	lock     sync.Mutex
+	resolved []msg.Message
+}
+
+func (w *mockWriter) ResolveMessage(m msg.Message) bool {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.resolved = append(w.resolved, m)
+	return true
+}
+
+func (w *mockWriter) nonces() []msg.Nonce {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	var nonces []msg.Nonce
+	for _, m := range w.resolved {
+		nonces = append(nonces, m.DepositNonce)
+	}
+	return nonces
+}
+
+func TestParseLimits(t *testing.T) {
+	rId := msg.ResourceId{31: 1}
+	limits, err := ParseLimits(fmt.Sprintf("0x%x:1000:24h,2/0x%x:10:1h", rId, rId))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if limit, ok := limits.Lookup(1, rId); !ok || limit.Amount.Cmp(big.NewInt(1000)) != 0 || limit.Window != 24*time.Hour {
+		t.Errorf("Got: %v Expected the limit of all routes", limit)
+	}
+	if limit, ok := limits.Lookup(2, rId); !ok || limit.Amount.Cmp(big.NewInt(10)) != 0 || limit.Window != time.Hour {
+		t.Errorf("Got: %v Expected the limit of the route from chain 2", limit)
+	}
+	if _, ok := limits.Lookup(1, msg.ResourceId{31: 2}); ok {
+		t.Error("expected no limit for other resources")
+	}
+
+	invalid := []string{
+		"0x01:1000:1h",
+		fmt.Sprintf("0x%x:1000", rId),
+		fmt.Sprintf("0x%x:-1:1h", rId),
+		fmt.Sprintf("0x%x:1000:0s", rId),
+		fmt.Sprintf("x/0x%x:1000:1h", rId),
+		fmt.Sprintf("0x%x:1:1h,0x%x:2:1h", rId, rId),
+	}
+	for _, s := range invalid {
+		if _, err := ParseLimits(s); err == nil {
+			t.Errorf("expected error for %s", s)
+		}
+	}
+}
+
+func TestBreaker(t *testing.T) {
+	dir := t.TempDir()
+	rId := msg.ResourceId{31: 1}
+	limits, err := ParseLimits(fmt.Sprintf("0x%x:1000:1h", rId))
+	if err != nil {
+		t.Fatal(err)
+	}
+	transfer := func(source msg.ChainId, nonce msg.Nonce, amount int64) msg.Message {
+		return msg.NewFungibleTransfer(source, 3, nonce, big.NewInt(amount), rId, []byte{0xab})
+	}
+
+	now := time.Unix(1600000000, 0)
+	w := &mockWriter{}
+	trips := make(chan msg.ChainId, 1)
+	b, err := NewBreaker(w, limits, func(source msg.ChainId, _ string) { trips <- source }, dir, 3, "relayer", log15.Root())
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.now = func() time.Time { return now }
+
+	b.ResolveMessage(transfer(1, 1, 600))
+	// Counted once if routed again
+	b.ResolveMessage(transfer(1, 1, 600))
+	// Other routes have their own volume
+	b.ResolveMessage(transfer(2, 1, 600))
+	// Older transfers leave the window
+	now = now.Add(time.Hour)
+	b.ResolveMessage(transfer(1, 2, 400))
+	b.ResolveMessage(transfer(1, 3, 600))
+	if nonces := w.nonces(); len(nonces) != 5 {
+		t.Fatalf("Got: %v Expected 5 resolved messages", nonces)
+	}
+
+	// Exceeding the limit halts the route, holding all of its messages
+	b.ResolveMessage(transfer(1, 4, 1))
+	b.ResolveMessage(msg.NewGenericTransfer(1, 3, 5, msg.ResourceId{31: 2}, []byte{}))
+	b.ResolveMessage(transfer(2, 2, 1))
+	select {
+	case source := <-trips:
+		if source != 1 {
+			t.Fatalf("Got: %d Expected route from 1 to trip", source)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("trip handler was not called")
+	}
+	if nonces := w.nonces(); len(nonces) != 6 {
+		t.Fatalf("Got: %v Expected the messages of the halted route to be held", nonces)
+	}
+
+	// Halted routes stay halted after a restart
+	b, err = NewBreaker(w, limits, nil, dir, 3, "relayer", log15.Root())
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.now = func() time.Time { return now }
+	b.ResolveMessage(transfer(1, 6, 1))
+
+	registry := NewRegistry()
+	registry.Register(b)
+	srv := httptest.NewServer(NewServer(registry))
+	defer srv.Close()
+	client := NewClient(srv.URL)
+
+	halted, err := client.Halted()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(halted) != 1 || halted[0].Source != 1 || len(halted[0].Held) != 3 {
+		t.Fatalf("Got: %+v Expected the route from 1 to be halted with 3 held messages", halted)
+	}
+	if _, err = client.Resume(3, 2, "alice", false); err == nil {
+		t.Fatal("expected error resuming a route that is not halted")
+	}
+	held, err := client.Resume(3, 1, "alice", false)
+	if err != nil || held != 3 {
+		t.Fatalf("Got: %d, %v Expected 3 held messages", held, err)
+	}
+	for i := 0; i < 100 && len(w.nonces()) < 9; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if nonces := w.nonces(); len(nonces) != 9 {
+		t.Fatalf("Got: %v Expected the held messages to be released", nonces)
+	}
+
+	// The volume of the route is reset on resume
+	b.ResolveMessage(transfer(1, 7, 1000))
+	if nonces := w.nonces(); len(nonces) != 10 {
+		t.Fatalf("Got: %v Expected the route to relay again", nonces)
+	}
+	if _, err = b.Resume(1, "alice", true); !errors.Is(err, ErrNotHalted) {
+		t.Fatalf("Got: %v Expected: %v", err, ErrNotHalted)
+	}
+}
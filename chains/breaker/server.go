@@ -0,0 +1,201 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package breaker
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/centrifuge/chainbridge-utils/msg"
+)
+
+// Registry holds the breakers of all chains of the relayer
+type Registry struct {
+	breakers map[msg.ChainId]*Breaker
+	lock     sync.RWMutex
+}
+
+func NewRegistry() *Registry {
+	return &Registry{breakers: make(map[msg.ChainId]*Breaker)}
+}
+
+// DefaultRegistry holds the breakers registered with Register
+var DefaultRegistry = NewRegistry()
+
+// Register adds the breaker to the DefaultRegistry
+func Register(b *Breaker) {
+	DefaultRegistry.Register(b)
+}
+
+// Register adds the breaker, replacing any breaker of the same chain
+func (r *Registry) Register(b *Breaker) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.breakers[b.Chain()] = b
+}
+
+// Breaker returns the breaker of the chain, nil if there is none
+func (r *Registry) Breaker(chain msg.ChainId) *Breaker {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.breakers[chain]
+}
+
+// Halted returns the halted routes of all chains
+func (r *Registry) Halted() []Halt {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	var halted []Halt
+	for _, b := range r.breakers {
+		halted = append(halted, b.Halted()...)
+	}
+	sort.SliceStable(halted, func(i, j int) bool { return halted[i].Chain < halted[j].Chain })
+	return halted
+}
+
+// Resumption is the body of resume requests
+type Resumption struct {
+	Operator string `json:"operator"`
+	Drop     bool   `json:"drop"` // Drops the held messages instead of relaying them
+}
+
+// Resumed is the response to resume requests
+type Resumed struct {
+	Held int `json:"held"`
+}
+
+// Server serves the admin endpoint:
+//
+//	GET  /breaker                           lists the halted routes of all chains
+//	POST /breaker/<chain>/<source>/resume   resumes the route from the source chain to the chain
+//
+// Resume requests take a Resumption, naming the operator.
+type Server struct {
+	registry *Registry
+}
+
+func NewServer(r *Registry) *Server {
+	return &Server{registry: r}
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	parts := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	if parts[0] != "breaker" {
+		http.NotFound(w, req)
+		return
+	}
+
+	if len(parts) == 1 {
+		if req.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		halted := s.registry.Halted()
+		if halted == nil {
+			halted = []Halt{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(halted)
+		return
+	}
+
+	if len(parts) != 4 || parts[3] != "resume" {
+		http.NotFound(w, req)
+		return
+	}
+	if req.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	chain, err := strconv.ParseUint(parts[1], 10, 8)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid chain ID %s", parts[1]), http.StatusBadRequest)
+		return
+	}
+	source, err := strconv.ParseUint(parts[2], 10, 8)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid source chain ID %s", parts[2]), http.StatusBadRequest)
+		return
+	}
+	b := s.registry.Breaker(msg.ChainId(chain))
+	if b == nil {
+		http.Error(w, fmt.Sprintf("no circuit breaker for chain %d", chain), http.StatusNotFound)
+		return
+	}
+	var r Resumption
+	if err = json.NewDecoder(req.Body).Decode(&r); err != nil || r.Operator == "" {
+		http.Error(w, "an operator is required", http.StatusBadRequest)
+		return
+	}
+
+	held, err := b.Resume(msg.ChainId(source), r.Operator, r.Drop)
+	if errors.Is(err, ErrNotHalted) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	} else if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(Resumed{Held: held})
+}
+
+// Client acts on the breakers of a running relayer through its admin endpoint
+type Client struct {
+	url string
+}
+
+// NewClient creates a client of the admin endpoint at the url (eg. http://127.0.0.1:8002)
+func NewClient(url string) *Client {
+	return &Client{url: strings.TrimSuffix(url, "/")}
+}
+
+// Halted returns the halted routes of all chains
+func (c *Client) Halted() ([]Halt, error) {
+	res, err := http.Get(c.url + "/breaker")
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, responseError(res)
+	}
+	var halted []Halt
+	return halted, json.NewDecoder(res.Body).Decode(&halted)
+}
+
+// Resume resumes the route from the source chain to the chain, returning the number of held messages
+func (c *Client) Resume(chain, source msg.ChainId, operator string, drop bool) (int, error) {
+	body, err := json.Marshal(Resumption{Operator: operator, Drop: drop})
+	if err != nil {
+		return 0, err
+	}
+	res, err := http.Post(fmt.Sprintf("%s/breaker/%d/%d/resume", c.url, chain, source), "application/json", bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return 0, responseError(res)
+	}
+	var resumed Resumed
+	if err = json.NewDecoder(res.Body).Decode(&resumed); err != nil {
+		return 0, err
+	}
+	return resumed.Held, nil
+}
+
+func responseError(res *http.Response) error {
+	body, _ := ioutil.ReadAll(res.Body)
+	return fmt.Errorf("%s: %s", res.Status, strings.TrimSpace(string(body)))
+}
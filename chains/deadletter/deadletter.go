@@ -0,0 +1,158 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+/*
+The deadletter package persists messages that a writer failed to resolve, along with the error that caused the
+failure. Entries are appended to a file per chain/relayer pair, so failed messages can be inspected and replayed.
+*/
+package deadletter
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/centrifuge/chainbridge-utils/msg"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const PathPostfix = ".chainbridge/deadletter"
+
+type Storer interface {
+	StoreMessage(m msg.Message, err error) error
+}
+
+// Rejection is implemented by errors of messages that were rejected on chain, as they failed validation.
+// The reason is stored along with the error.
+type Rejection interface {
+	RejectReason() string
+}
+
+var _ Storer = &EmptyStore{}
+var _ Storer = &Store{}
+
+// Dummy store for testing only
+type EmptyStore struct{}
+
+func (s *EmptyStore) StoreMessage(_ msg.Message, _ error) error { return nil }
+
+// Entry is a failed message as written to disk
+type Entry struct {
+	Time         time.Time        `json:"time"`
+	Source       msg.ChainId      `json:"source"`
+	Destination  msg.ChainId      `json:"destination"`
+	Type         msg.TransferType `json:"type"`
+	DepositNonce msg.Nonce        `json:"depositNonce"`
+	ResourceId   string           `json:"resourceId"`
+	Payload      []interface{}    `json:"payload"`
+	Error        string           `json:"error"`
+	Reason       string           `json:"reason,omitempty"` // Set if the message was rejected
+}
+
+// Store implements Storer by appending entries to a file.
+type Store struct {
+	path     string // Path excluding filename
+	fullPath string
+	lock     sync.Mutex
+}
+
+func NewStore(path string, chain msg.ChainId, relayer string) (*Store, error) {
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		path = filepath.Join(home, PathPostfix)
+	}
+
+	return &Store{
+		path:     path,
+		fullPath: filepath.Join(path, fmt.Sprintf("%s-%d.deadletter", relayer, chain)),
+	}, nil
+}
+
+// StoreMessage appends the message and the error that caused it to fail to disk.
+func (s *Store) StoreMessage(m msg.Message, cause error) error {
+	entry := Entry{
+		Time:         time.Now().UTC(),
+		Source:       m.Source,
+		Destination:  m.Destination,
+		Type:         m.Type,
+		DepositNonce: m.DepositNonce,
+		ResourceId:   m.ResourceId.Hex(),
+		Payload:      make([]interface{}, len(m.Payload)),
+	}
+	if cause != nil {
+		entry.Error = cause.Error()
+	}
+	var rejection Rejection
+	if errors.As(cause, &rejection) {
+		entry.Reason = rejection.RejectReason()
+	}
+	// Byte payloads are stored as hex, anything else as is
+	for i, p := range m.Payload {
+		if bz, ok := p.([]byte); ok {
+			entry.Payload[i] = hexutil.Bytes(bz)
+		} else {
+			entry.Payload[i] = fmt.Sprintf("%v", p)
+		}
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// Create dir if it does not exist
+	if _, err := os.Stat(s.path); os.IsNotExist(err) {
+		errr := os.MkdirAll(s.path, os.ModePerm)
+		if errr != nil {
+			return errr
+		}
+	}
+
+	f, err := os.OpenFile(s.fullPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(data, '\n'))
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Load reads all entries from disk, returning none if the file does not exist.
+func (s *Store) Load() ([]Entry, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	f, err := os.Open(s.fullPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
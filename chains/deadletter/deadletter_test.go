@@ -0,0 +1,94 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package deadletter
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/centrifuge/chainbridge-utils/msg"
+)
+
+func TestStore(t *testing.T) {
+	dir, err := os.MkdirTemp("", "deadletter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	store, err := NewStore(dir, msg.ChainId(1), "relayer")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("expected no entries, got %d", len(entries))
+	}
+
+	m := msg.NewFungibleTransfer(2, 1, 10, big.NewInt(100), msg.ResourceId{1}, []byte{0xab, 0xcd})
+	err = store.StoreMessage(m, errors.New("failed to construct proposal"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = store.StoreMessage(msg.NewGenericTransfer(2, 1, 11, msg.ResourceId{2}, []byte{}), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err = store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Got: %d Expected: %d", len(entries), 2)
+	}
+	if entries[0].DepositNonce != 10 || entries[0].Error != "failed to construct proposal" {
+		t.Fatalf("unexpected entry: %#v", entries[0])
+	}
+	if entries[0].Payload[1] != "0xabcd" {
+		t.Fatalf("Got: %v Expected: %s", entries[0].Payload[1], "0xabcd")
+	}
+	if entries[1].Type != msg.GenericTransfer || entries[1].Error != "" {
+		t.Fatalf("unexpected entry: %#v", entries[1])
+	}
+}
+
+type rejectedErr struct{}
+
+func (rejectedErr) Error() string        { return "invalid recipient" }
+func (rejectedErr) RejectReason() string { return "invalid_recipient" }
+
+func TestStore_RejectReason(t *testing.T) {
+	dir, err := os.MkdirTemp("", "deadletter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	store, err := NewStore(dir, msg.ChainId(1), "relayer")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := msg.NewGenericTransfer(2, 1, 12, msg.ResourceId{3}, []byte{})
+	err = store.StoreMessage(m, fmt.Errorf("rejected: %w", rejectedErr{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Reason != "invalid_recipient" {
+		t.Fatalf("unexpected entries: %#v", entries)
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+/*
+The decimals package converts fungible amounts between chains that represent a token with a different number of
+decimals. Conversions are configured per resource ID on the destination chain, and applied by its writer before
+a proposal is constructed.
+
+A table is configured as a comma separated list of entries in the form
+
+	[<sourceChainId>/]<resourceId>:<sourceDecimals>:<destinationDecimals>
+
+Entries with a source chain ID only apply to transfers from that chain, and take precedence over entries without one.
+Amounts of resources without an entry are passed unchanged.
+*/
+package decimals
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/centrifuge/chainbridge-utils/msg"
+)
+
+// MaxDecimals bounds the configured decimals, to keep the scaling factors reasonable
+const MaxDecimals = 77
+
+var ErrPrecisionLoss = errors.New("amount cannot be converted without loss of precision")
+var ErrOverflow = errors.New("amount overflows the destination type")
+
+// Conversion scales amounts from the decimals of the source chain to those of the destination chain
+type Conversion struct {
+	Source      uint8
+	Destination uint8
+}
+
+// Convert scales the amount. Scaling down fails with ErrPrecisionLoss if any of the dropped digits is non-zero.
+func (c Conversion) Convert(amount *big.Int) (*big.Int, error) {
+	switch {
+	case c.Destination > c.Source:
+		factor := pow10(c.Destination - c.Source)
+		return new(big.Int).Mul(amount, factor), nil
+	case c.Destination < c.Source:
+		factor := pow10(c.Source - c.Destination)
+		res, rem := new(big.Int).QuoRem(amount, factor, new(big.Int))
+		if rem.Sign() != 0 {
+			return nil, fmt.Errorf("%w: %s from %d to %d decimals", ErrPrecisionLoss, amount, c.Source, c.Destination)
+		}
+		return res, nil
+	default:
+		return new(big.Int).Set(amount), nil
+	}
+}
+
+func pow10(n uint8) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+type route struct {
+	source   msg.ChainId
+	resource msg.ResourceId
+}
+
+// Table holds the conversions of the resources transferred to a chain
+type Table struct {
+	resources map[msg.ResourceId]Conversion
+	routes    map[route]Conversion
+}
+
+// NewTable returns a table without any conversions
+func NewTable() *Table {
+	return &Table{
+		resources: make(map[msg.ResourceId]Conversion),
+		routes:    make(map[route]Conversion),
+	}
+}
+
+// Parse constructs a table from its configured form (see package documentation)
+func Parse(s string) (*Table, error) {
+	t := NewTable()
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if err := t.parseEntry(entry); err != nil {
+			return nil, fmt.Errorf("invalid decimals entry %q: %w", entry, err)
+		}
+	}
+	return t, nil
+}
+
+func (t *Table) parseEntry(entry string) error {
+	parts := strings.Split(entry, ":")
+	if len(parts) != 3 {
+		return errors.New("expected [<sourceChainId>/]<resourceId>:<sourceDecimals>:<destinationDecimals>")
+	}
+
+	id, src := parts[0], ""
+	if i := strings.Index(id, "/"); i >= 0 {
+		src, id = id[:i], id[i+1:]
+	}
+
+	rIdBytes, err := hex.DecodeString(strings.TrimPrefix(id, "0x"))
+	if err != nil || len(rIdBytes) != 32 {
+		return fmt.Errorf("invalid resource ID %s", id)
+	}
+	rId := msg.ResourceIdFromSlice(rIdBytes)
+
+	var conv Conversion
+	if conv.Source, err = parseDecimals(parts[1]); err != nil {
+		return err
+	}
+	if conv.Destination, err = parseDecimals(parts[2]); err != nil {
+		return err
+	}
+
+	if src == "" {
+		t.resources[rId] = conv
+		return nil
+	}
+	chainId, err := strconv.ParseUint(src, 10, 8)
+	if err != nil {
+		return fmt.Errorf("invalid source chain ID %s", src)
+	}
+	t.routes[route{source: msg.ChainId(chainId), resource: rId}] = conv
+	return nil
+}
+
+func parseDecimals(s string) (uint8, error) {
+	d, err := strconv.ParseUint(s, 10, 8)
+	if err != nil || d > MaxDecimals {
+		return 0, fmt.Errorf("invalid decimals %s, expected 0 to %d", s, MaxDecimals)
+	}
+	return uint8(d), nil
+}
+
+// Lookup returns the conversion of the resource for transfers from the source chain
+func (t *Table) Lookup(source msg.ChainId, rId msg.ResourceId) (Conversion, bool) {
+	if t == nil {
+		return Conversion{}, false
+	}
+	if conv, ok := t.routes[route{source: source, resource: rId}]; ok {
+		return conv, true
+	}
+	conv, ok := t.resources[rId]
+	return conv, ok
+}
+
+// Convert scales the amount of a transfer from the source chain, if a conversion is configured for the resource.
+// It fails with ErrOverflow if the result does not fit into an unsigned integer of the given number of bits.
+func (t *Table) Convert(source msg.ChainId, rId msg.ResourceId, amount *big.Int, bits int) (*big.Int, error) {
+	res := amount
+	if conv, ok := t.Lookup(source, rId); ok {
+		var err error
+		res, err = conv.Convert(amount)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if res.Sign() < 0 || res.BitLen() > bits {
+		return nil, fmt.Errorf("%w: %s exceeds %d bits", ErrOverflow, res, bits)
+	}
+	return res, nil
+}
@@ -0,0 +1,108 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package decimals
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/chainbridge-utils/msg"
+)
+
+func amount(s string) *big.Int {
+	res, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		panic(fmt.Sprintf("invalid amount %s", s))
+	}
+	return res
+}
+
+func TestParse(t *testing.T) {
+	rId := msg.ResourceId{1}
+	other := msg.ResourceId{2}
+	table, err := Parse(fmt.Sprintf(" 0x%x:18:12, 2/%x:6:12,", rId, rId))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		source   msg.ChainId
+		rId      msg.ResourceId
+		expected Conversion
+		ok       bool
+	}{
+		{source: 0, rId: rId, expected: Conversion{Source: 18, Destination: 12}, ok: true},
+		{source: 2, rId: rId, expected: Conversion{Source: 6, Destination: 12}, ok: true},
+		{source: 0, rId: other},
+	}
+	for _, tc := range testCases {
+		conv, ok := table.Lookup(tc.source, tc.rId)
+		if ok != tc.ok || conv != tc.expected {
+			t.Fatalf("source %d: Got: %v (%t) Expected: %v (%t)", tc.source, conv, ok, tc.expected, tc.ok)
+		}
+	}
+
+	for _, invalid := range []string{
+		"0x01:18:12",
+		fmt.Sprintf("0x%x:18", rId),
+		fmt.Sprintf("0x%x:18:78", rId),
+		fmt.Sprintf("0x%x:-1:12", rId),
+		fmt.Sprintf("256/0x%x:18:12", rId),
+	} {
+		if _, err := Parse(invalid); err == nil {
+			t.Fatalf("expected error for %q", invalid)
+		}
+	}
+}
+
+func TestTable_Convert(t *testing.T) {
+	rId := msg.ResourceId{1}
+	table, err := Parse(fmt.Sprintf("0x%x:18:12,1/0x%x:12:18,2/0x%x:6:6", rId, rId, rId))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name     string
+		source   msg.ChainId
+		rId      msg.ResourceId
+		amount   *big.Int
+		bits     int
+		expected *big.Int
+		err      error
+	}{
+		{name: "scale down", source: 0, rId: rId, amount: amount("1500000000000000000"), bits: 128, expected: amount("1500000000000")},
+		{name: "precision loss", source: 0, rId: rId, amount: amount("1000000000000000001"), bits: 128, err: ErrPrecisionLoss},
+		{name: "scale up", source: 1, rId: rId, amount: amount("1500000000000"), bits: 256, expected: amount("1500000000000000000")},
+		{name: "same decimals", source: 2, rId: rId, amount: amount("123"), bits: 256, expected: amount("123")},
+		{name: "scale up overflow", source: 1, rId: rId, amount: new(big.Int).Lsh(big.NewInt(1), 127), bits: 128, err: ErrOverflow},
+		{name: "no conversion", source: 0, rId: msg.ResourceId{2}, amount: amount("1000000000000000001"), bits: 128, expected: amount("1000000000000000001")},
+		{name: "no conversion overflow", source: 0, rId: msg.ResourceId{2}, amount: new(big.Int).Lsh(big.NewInt(1), 128), bits: 128, err: ErrOverflow},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := table.Convert(tc.source, tc.rId, tc.amount, tc.bits)
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("Got: %v Expected: %v", err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.Cmp(tc.expected) != 0 {
+				t.Fatalf("Got: %s Expected: %s", res, tc.expected)
+			}
+		})
+	}
+
+	// A nil table passes amounts unchanged, but still checks the bounds
+	var empty *Table
+	if _, err := empty.Convert(0, rId, new(big.Int).Lsh(big.NewInt(1), 128), 128); !errors.Is(err, ErrOverflow) {
+		t.Fatalf("Got: %v Expected: %v", err, ErrOverflow)
+	}
+}
@@ -0,0 +1,375 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package ethereum
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ChainSafe/ChainBridge/bindings/Bridge"
+	"github.com/ChainSafe/ChainBridge/bindings/BridgeV2"
+	utils "github.com/ChainSafe/ChainBridge/shared/ethereum"
+	"github.com/centrifuge/chainbridge-utils/msg"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// Supported versions of the Bridge contract
+const (
+	// LegacyContractVersion emits Deposit(uint8,bytes32,uint64), the deposit details are read from the handler records
+	LegacyContractVersion = "1"
+	// V2ContractVersion emits the deposit data in the Deposit event and charges fees with a fee handler
+	V2ContractVersion = "2"
+)
+
+const DefaultContractVersion = LegacyContractVersion
+
+// ErrNotAdmin is returned by admin calls if the relayer does not have admin rights
+var ErrNotAdmin = errors.New("relayer is not an admin of the bridge")
+
+// depositEvent is a deposit made to the bridge contract
+type depositEvent struct {
+	destId msg.ChainId
+	rId    msg.ResourceId
+	nonce  msg.Nonce
+	data   []byte         // Deposit data of the event, nil if it must be read from the handler
+	sender common.Address // Depositor, set if the event includes it
+}
+
+// proposalEvent is a change of a proposal's status
+type proposalEvent struct {
+	source msg.ChainId
+	nonce  msg.Nonce
+	status uint8
+}
+
+// voteEvent is a vote cast for a proposal
+type voteEvent struct {
+	source   msg.ChainId
+	nonce    msg.Nonce
+	dataHash [32]byte
+	voter    common.Address // Sender of the vote transaction
+}
+
+// bridgeAdapter hides the differences between versions of the Bridge contract from the listener and writer
+type bridgeAdapter interface {
+	// chainId returns the chain ID of the bridge
+	chainId(opts *bind.CallOpts) (uint8, error)
+	// handlerAddress returns the handler of the resource ID
+	handlerAddress(opts *bind.CallOpts, rId msg.ResourceId) (common.Address, error)
+	// deposits returns the deposits made in the block
+	deposits(block *big.Int) ([]depositEvent, error)
+	// proposalEvents returns the proposal status changes of the block
+	proposalEvents(block *big.Int) ([]proposalEvent, error)
+	// proposalVotes returns the votes cast in the block
+	proposalVotes(block *big.Int) ([]voteEvent, error)
+	// proposalStatus returns the status of the proposal
+	proposalStatus(opts *bind.CallOpts, srcId msg.ChainId, nonce msg.Nonce, dataHash [32]byte) (uint8, error)
+	// hasVoted returns true if the relayer voted for the proposal
+	hasVoted(opts *bind.CallOpts, srcId msg.ChainId, nonce msg.Nonce, dataHash [32]byte, relayer common.Address) (bool, error)
+	// voteProposal submits a vote for the proposal of the message
+	voteProposal(opts *bind.TransactOpts, m msg.Message, data []byte, dataHash [32]byte) (*types.Transaction, error)
+	// executeProposal submits the execution of the proposal of the message
+	executeProposal(opts *bind.TransactOpts, m msg.Message, data []byte) (*types.Transaction, error)
+	// pauseTransfers pauses deposits and executions, the relayer must have admin rights
+	pauseTransfers(opts *bind.TransactOpts) (*types.Transaction, error)
+}
+
+var _ bridgeAdapter = &legacyBridge{}
+var _ bridgeAdapter = &v2Bridge{}
+
+// newBridgeAdapter binds the bridge contract of the given version
+func newBridgeAdapter(version string, address common.Address, client *ethclient.Client) (bridgeAdapter, error) {
+	switch version {
+	case LegacyContractVersion:
+		return newLegacyBridge(address, client)
+	case V2ContractVersion:
+		return newV2Bridge(address, client)
+	default:
+		return nil, fmt.Errorf("unsupported contract version: %s", version)
+	}
+}
+
+// legacyBridge binds the Bridge contract in bindings/Bridge
+type legacyBridge struct {
+	address  common.Address
+	client   *ethclient.Client
+	contract *Bridge.Bridge
+	abi      abi.ABI // Used to decode vote transactions
+}
+
+func newLegacyBridge(address common.Address, client *ethclient.Client) (*legacyBridge, error) {
+	contract, err := Bridge.NewBridge(address, client)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := abi.JSON(strings.NewReader(Bridge.BridgeABI))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse bridge ABI: %w", err)
+	}
+	return &legacyBridge{address: address, client: client, contract: contract, abi: parsed}, nil
+}
+
+func (b *legacyBridge) chainId(opts *bind.CallOpts) (uint8, error) {
+	return b.contract.ChainID(opts)
+}
+
+func (b *legacyBridge) handlerAddress(opts *bind.CallOpts, rId msg.ResourceId) (common.Address, error) {
+	return b.contract.ResourceIDToHandlerAddress(opts, rId)
+}
+
+func (b *legacyBridge) deposits(block *big.Int) ([]depositEvent, error) {
+	query := buildQuery(b.address, utils.Deposit, block, block)
+	logs, err := b.client.FilterLogs(context.Background(), query)
+	if err != nil {
+		return nil, fmt.Errorf("unable to Filter Logs: %w", err)
+	}
+
+	deposits := make([]depositEvent, 0, len(logs))
+	for _, log := range logs {
+		deposits = append(deposits, depositEvent{
+			destId: msg.ChainId(log.Topics[1].Big().Uint64()),
+			rId:    msg.ResourceIdFromSlice(log.Topics[2].Bytes()),
+			nonce:  msg.Nonce(log.Topics[3].Big().Uint64()),
+		})
+	}
+	return deposits, nil
+}
+
+func (b *legacyBridge) proposalEvents(block *big.Int) ([]proposalEvent, error) {
+	query := buildQuery(b.address, utils.ProposalEvent, block, block)
+	logs, err := b.client.FilterLogs(context.Background(), query)
+	if err != nil {
+		return nil, err
+	}
+
+	evts := make([]proposalEvent, 0, len(logs))
+	for _, log := range logs {
+		evts = append(evts, proposalEvent{
+			source: msg.ChainId(log.Topics[1].Big().Uint64()),
+			nonce:  msg.Nonce(log.Topics[2].Big().Uint64()),
+			status: uint8(log.Topics[3].Big().Uint64()),
+		})
+	}
+	return evts, nil
+}
+
+// proposalVotes reads the data hash from the vote transaction, as the legacy ProposalVote event does not include it.
+// Votes not submitted by calling voteProposal directly are skipped.
+func (b *legacyBridge) proposalVotes(block *big.Int) ([]voteEvent, error) {
+	query := buildQuery(b.address, utils.ProposalVote, block, block)
+	logs, err := b.client.FilterLogs(context.Background(), query)
+	if err != nil {
+		return nil, err
+	}
+
+	var votes []voteEvent
+	for _, log := range logs {
+		tx, voter, err := voteTransaction(b.client, log.TxHash)
+		if err != nil {
+			return nil, err
+		}
+		dataHash, ok := b.voteDataHash(tx.Data())
+		if !ok {
+			continue
+		}
+		votes = append(votes, voteEvent{
+			source:   msg.ChainId(log.Topics[1].Big().Uint64()),
+			nonce:    msg.Nonce(log.Topics[2].Big().Uint64()),
+			dataHash: dataHash,
+			voter:    voter,
+		})
+	}
+	return votes, nil
+}
+
+// voteDataHash decodes the data hash argument of a voteProposal call
+func (b *legacyBridge) voteDataHash(input []byte) ([32]byte, bool) {
+	method, ok := b.abi.Methods["voteProposal"]
+	if !ok || len(input) < 4 || !bytes.Equal(input[:4], method.ID) {
+		return [32]byte{}, false
+	}
+	args, err := method.Inputs.Unpack(input[4:])
+	if err != nil || len(args) != 4 {
+		return [32]byte{}, false
+	}
+	dataHash, ok := args[3].([32]byte)
+	return dataHash, ok
+}
+
+func (b *legacyBridge) proposalStatus(opts *bind.CallOpts, srcId msg.ChainId, nonce msg.Nonce, dataHash [32]byte) (uint8, error) {
+	prop, err := b.contract.GetProposal(opts, uint8(srcId), uint64(nonce), dataHash)
+	if err != nil {
+		return 0, err
+	}
+	return prop.Status, nil
+}
+
+func (b *legacyBridge) hasVoted(opts *bind.CallOpts, srcId msg.ChainId, nonce msg.Nonce, dataHash [32]byte, relayer common.Address) (bool, error) {
+	return b.contract.HasVotedOnProposal(opts, utils.IDAndNonce(srcId, nonce), dataHash, relayer)
+}
+
+// voteProposal votes for the data hash, the data is only submitted on execution
+func (b *legacyBridge) voteProposal(opts *bind.TransactOpts, m msg.Message, _ []byte, dataHash [32]byte) (*types.Transaction, error) {
+	return b.contract.VoteProposal(opts, uint8(m.Source), uint64(m.DepositNonce), m.ResourceId, dataHash)
+}
+
+func (b *legacyBridge) executeProposal(opts *bind.TransactOpts, m msg.Message, data []byte) (*types.Transaction, error) {
+	return b.contract.ExecuteProposal(opts, uint8(m.Source), uint64(m.DepositNonce), data, m.ResourceId)
+}
+
+// pauseTransfers checks the relayer has the admin role, as the transaction would fail otherwise
+func (b *legacyBridge) pauseTransfers(opts *bind.TransactOpts) (*types.Transaction, error) {
+	callOpts := &bind.CallOpts{From: opts.From}
+	role, err := b.contract.DEFAULTADMINROLE(callOpts)
+	if err != nil {
+		return nil, err
+	}
+	isAdmin, err := b.contract.HasRole(callOpts, role, opts.From)
+	if err != nil {
+		return nil, err
+	}
+	if !isAdmin {
+		return nil, ErrNotAdmin
+	}
+	return b.contract.AdminPauseTransfers(opts)
+}
+
+// v2Bridge binds the Bridge contract in bindings/BridgeV2
+type v2Bridge struct {
+	client   *ethclient.Client
+	contract *BridgeV2.BridgeV2
+}
+
+func newV2Bridge(address common.Address, client *ethclient.Client) (*v2Bridge, error) {
+	contract, err := BridgeV2.NewBridgeV2(address, client)
+	if err != nil {
+		return nil, err
+	}
+	return &v2Bridge{client: client, contract: contract}, nil
+}
+
+func (b *v2Bridge) chainId(opts *bind.CallOpts) (uint8, error) {
+	return b.contract.DomainID(opts)
+}
+
+func (b *v2Bridge) handlerAddress(opts *bind.CallOpts, rId msg.ResourceId) (common.Address, error) {
+	return b.contract.ResourceIDToHandlerAddress(opts, rId)
+}
+
+func (b *v2Bridge) deposits(block *big.Int) ([]depositEvent, error) {
+	end := block.Uint64()
+	it, err := b.contract.FilterDeposit(&bind.FilterOpts{Start: end, End: &end}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to Filter Logs: %w", err)
+	}
+	defer it.Close()
+
+	var deposits []depositEvent
+	for it.Next() {
+		data := it.Event.Data
+		if data == nil {
+			data = []byte{}
+		}
+		deposits = append(deposits, depositEvent{
+			destId: msg.ChainId(it.Event.DestinationDomainID),
+			rId:    it.Event.ResourceID,
+			nonce:  msg.Nonce(it.Event.DepositNonce),
+			data:   data,
+			sender: it.Event.User,
+		})
+	}
+	return deposits, it.Error()
+}
+
+func (b *v2Bridge) proposalEvents(block *big.Int) ([]proposalEvent, error) {
+	end := block.Uint64()
+	it, err := b.contract.FilterProposalEvent(&bind.FilterOpts{Start: end, End: &end})
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	var evts []proposalEvent
+	for it.Next() {
+		evts = append(evts, proposalEvent{
+			source: msg.ChainId(it.Event.OriginDomainID),
+			nonce:  msg.Nonce(it.Event.DepositNonce),
+			status: it.Event.Status,
+		})
+	}
+	return evts, it.Error()
+}
+
+func (b *v2Bridge) proposalVotes(block *big.Int) ([]voteEvent, error) {
+	end := block.Uint64()
+	it, err := b.contract.FilterProposalVote(&bind.FilterOpts{Start: end, End: &end})
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	var votes []voteEvent
+	for it.Next() {
+		_, voter, err := voteTransaction(b.client, it.Event.Raw.TxHash)
+		if err != nil {
+			return nil, err
+		}
+		votes = append(votes, voteEvent{
+			source:   msg.ChainId(it.Event.OriginDomainID),
+			nonce:    msg.Nonce(it.Event.DepositNonce),
+			dataHash: it.Event.DataHash,
+			voter:    voter,
+		})
+	}
+	return votes, it.Error()
+}
+
+func (b *v2Bridge) proposalStatus(opts *bind.CallOpts, srcId msg.ChainId, nonce msg.Nonce, dataHash [32]byte) (uint8, error) {
+	prop, err := b.contract.GetProposal(opts, uint8(srcId), uint64(nonce), dataHash)
+	if err != nil {
+		return 0, err
+	}
+	return prop.Status, nil
+}
+
+func (b *v2Bridge) hasVoted(opts *bind.CallOpts, srcId msg.ChainId, nonce msg.Nonce, dataHash [32]byte, relayer common.Address) (bool, error) {
+	return b.contract.HasVotedOnProposal(opts, utils.IDAndNonce(srcId, nonce), dataHash, relayer)
+}
+
+// voteProposal votes with the data, the bridge derives the data hash from the data and the resource's handler
+func (b *v2Bridge) voteProposal(opts *bind.TransactOpts, m msg.Message, data []byte, _ [32]byte) (*types.Transaction, error) {
+	return b.contract.VoteProposal(opts, uint8(m.Source), uint64(m.DepositNonce), m.ResourceId, data)
+}
+
+// executeProposal executes the proposal, reverting if the handler fails so the execution can be retried
+func (b *v2Bridge) executeProposal(opts *bind.TransactOpts, m msg.Message, data []byte) (*types.Transaction, error) {
+	return b.contract.ExecuteProposal(opts, uint8(m.Source), uint64(m.DepositNonce), data, m.ResourceId, true)
+}
+
+// pauseTransfers relies on the transaction failing to estimate gas if the relayer lacks access, as access to admin
+// functions is delegated to an access control contract
+func (b *v2Bridge) pauseTransfers(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return b.contract.AdminPauseTransfers(opts)
+}
+
+// voteTransaction returns the transaction of a vote and its sender, the voting relayer
+func voteTransaction(client *ethclient.Client, txHash common.Hash) (*types.Transaction, common.Address, error) {
+	tx, _, err := client.TransactionByHash(context.Background(), txHash)
+	if err != nil {
+		return nil, common.Address{}, fmt.Errorf("unable to fetch vote transaction %s: %w", txHash.Hex(), err)
+	}
+	voter, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return nil, common.Address{}, fmt.Errorf("unable to recover sender of vote transaction %s: %w", txHash.Hex(), err)
+	}
+	return tx, voter, nil
+}
@@ -4,7 +4,7 @@
 The ethereum package contains the logic for interacting with ethereum chains.
 
 There are 3 major components: the connection, the listener, and the writer.
-The currently supported transfer types are Fungible (ERC20), Non-Fungible (ERC721), and generic.
+The currently supported transfer types are Fungible (ERC20), Non-Fungible (ERC721), Semi-Fungible (ERC1155), and generic.
 
 Connection
 
@@ -12,7 +12,7 @@ The connection contains the ethereum RPC client and can be accessed by both the
 
 Listener
 
-The listener polls for each new block and looks for deposit events in the bridge contract. If a deposit occurs, the listener will fetch additional information from the handler before constructing a message and forwarding it to the router.
+The listener polls for each new block and looks for deposit events in the bridge contract. If a deposit occurs, the listener will fetch additional information from the handler before constructing a message and forwarding it to the router. Newer bridge contracts (see the contractVersion option) include this information in the deposit event.
 
 Writer
 
@@ -25,10 +25,13 @@ import (
 	"fmt"
 	"math/big"
 
-	bridge "github.com/ChainSafe/ChainBridge/bindings/Bridge"
+	erc1155Handler "github.com/ChainSafe/ChainBridge/bindings/ERC1155Handler"
 	erc20Handler "github.com/ChainSafe/ChainBridge/bindings/ERC20Handler"
 	erc721Handler "github.com/ChainSafe/ChainBridge/bindings/ERC721Handler"
 	"github.com/ChainSafe/ChainBridge/bindings/GenericHandler"
+	"github.com/ChainSafe/ChainBridge/chains/approval"
+	"github.com/ChainSafe/ChainBridge/chains/breaker"
+	"github.com/ChainSafe/ChainBridge/chains/deadletter"
 	connection "github.com/ChainSafe/ChainBridge/connections/ethereum"
 	"github.com/ChainSafe/log15"
 	"github.com/centrifuge/chainbridge-utils/blockstore"
@@ -65,6 +68,7 @@ type Chain struct {
 	conn     Connection        // THe chains connection
 	listener *listener         // The listener of this chain
 	writer   *writer           // The writer of the chain
+	resolver core.Writer       // Resolves messages routed to this chain, the writer wrapped by any circuit breaker, approval queue and vote monitor
 	stop     chan<- int
 }
 
@@ -126,12 +130,12 @@ func InitializeChain(chainCfg *core.ChainConfig, logger log15.Logger, sysErr cha
 		return nil, err
 	}
 
-	bridgeContract, err := bridge.NewBridge(cfg.bridgeContract, conn.Client())
+	bridgeContract, err := newBridgeAdapter(cfg.contractVersion, cfg.bridgeContract, conn.Client())
 	if err != nil {
 		return nil, err
 	}
 
-	chainId, err := bridgeContract.ChainID(conn.CallOpts())
+	chainId, err := bridgeContract.chainId(conn.CallOpts())
 	if err != nil {
 		return nil, err
 	}
@@ -159,6 +163,11 @@ func InitializeChain(chainCfg *core.ChainConfig, logger log15.Logger, sysErr cha
 		return nil, err
 	}
 
+	erc1155HandlerContract, err := erc1155Handler.NewERC1155Handler(cfg.erc1155HandlerContract, conn.Client())
+	if err != nil {
+		return nil, err
+	}
+
 	genericHandlerContract, err := GenericHandler.NewGenericHandler(cfg.genericHandlerContract, conn.Client())
 	if err != nil {
 		return nil, err
@@ -172,23 +181,77 @@ func InitializeChain(chainCfg *core.ChainConfig, logger log15.Logger, sysErr cha
 		cfg.startBlock = curr
 	}
 
-	listener := NewListener(conn, cfg, logger, bs, stop, sysErr, m)
-	listener.setContracts(bridgeContract, erc20HandlerContract, erc721HandlerContract, genericHandlerContract)
+	dl, err := deadletter.NewStore(cfg.blockstorePath, cfg.id, kp.Address())
+	if err != nil {
+		return nil, err
+	}
+
+	// Loaded even if no tiers are set, so deposits held before a config change are still released
+	holds, err := newHoldStore(cfg.blockstorePath, cfg.id, kp.Address())
+	if err != nil {
+		return nil, err
+	}
+	if m != nil {
+		holds.registerMetrics(cfg.name)
+	}
+
+	listener := NewListener(conn, cfg, logger, bs, stop, sysErr, m, dl)
+	listener.setContracts(bridgeContract, erc20HandlerContract, erc721HandlerContract, erc1155HandlerContract, genericHandlerContract)
+	listener.holds = holds
 
 	writer := NewWriter(conn, cfg, logger, stop, sysErr, m)
-	writer.setContract(bridgeContract)
+	writer.setContract(bridgeContract, genericHandlerContract)
+
+	var resolver core.Writer = writer
+	if cfg.limits != nil {
+		// Wrapped by the approval queue, so transfers count towards the volume once released
+		var onTrip breaker.TripHandler
+		if cfg.pauseOnBreach {
+			onTrip = writer.pauseTransfers
+		}
+		b, err := breaker.NewBreaker(resolver, cfg.limits, onTrip, cfg.blockstorePath, cfg.id, kp.Address(), logger)
+		if err != nil {
+			return nil, err
+		}
+		if m != nil {
+			b.RegisterMetrics(cfg.name)
+		}
+		breaker.Register(b)
+		resolver = b
+	}
+	if cfg.approvals != nil {
+		queue, err := approval.NewQueue(resolver, cfg.approvals, cfg.blockstorePath, cfg.id, kp.Address(), logger)
+		if err != nil {
+			return nil, err
+		}
+		if m != nil {
+			queue.RegisterMetrics(cfg.name)
+		}
+		approval.Register(queue)
+		resolver = queue
+	}
+	if cfg.monitorVotes {
+		// Wraps the other resolvers, so the data hash of every message routed to this chain is known
+		votes := newVoteMonitor(resolver, writer.proposalData, kp.CommonAddress(), logger)
+		if m != nil {
+			votes.registerMetrics(cfg.name)
+		}
+		listener.votes = votes
+		resolver = votes
+	}
 
 	return &Chain{
 		cfg:      chainCfg,
 		conn:     conn,
 		writer:   writer,
 		listener: listener,
+		resolver: resolver,
 		stop:     stop,
 	}, nil
 }
 
 func (c *Chain) SetRouter(r *core.Router) {
-	r.Listen(c.cfg.Id, c.writer)
+	r.Listen(c.cfg.Id, c.resolver)
 	c.listener.setRouter(r)
 }
 
@@ -6,11 +6,17 @@ package ethereum
 import (
 	"errors"
 	"fmt"
+	"math/big"
+
+	"github.com/ChainSafe/ChainBridge/chains/approval"
+	"github.com/ChainSafe/ChainBridge/chains/breaker"
+	"github.com/ChainSafe/ChainBridge/chains/decimals"
+	"github.com/ChainSafe/ChainBridge/chains/generic"
+	"github.com/ChainSafe/ChainBridge/chains/policy"
 	utils "github.com/ChainSafe/ChainBridge/shared/ethereum"
 	"github.com/centrifuge/chainbridge-utils/core"
 	"github.com/centrifuge/chainbridge-utils/msg"
 	"github.com/ethereum/go-ethereum/common"
-	"math/big"
 )
 
 const DefaultGasLimit = 6721975
@@ -23,6 +29,7 @@ var (
 	BridgeOpt             = "bridge"
 	Erc20HandlerOpt       = "erc20Handler"
 	Erc721HandlerOpt      = "erc721Handler"
+	Erc1155HandlerOpt     = "erc1155Handler"
 	GenericHandlerOpt     = "genericHandler"
 	MaxGasPriceOpt        = "maxGasPrice"
 	GasLimitOpt           = "gasLimit"
@@ -31,6 +38,15 @@ var (
 	StartBlockOpt         = "startBlock"
 	BlockConfirmationsOpt = "blockConfirmations"
 	MainChainIdOpt        = "mainChainId"
+	ResourceDecimalsOpt   = "resourceDecimals"
+	ContractVersionOpt    = "contractVersion"
+	GenericCallsOpt       = "genericCalls"
+	DepositPoliciesOpt    = "depositPolicies"
+	ConfirmationTiersOpt  = "confirmationTiers"
+	ApprovalThresholdsOpt = "approvalThresholds"
+	VolumeLimitsOpt       = "volumeLimits"
+	PauseOnBreachOpt      = "pauseOnBreach"
+	MonitorVotesOpt       = "monitorVotes"
 )
 
 // Config encapsulates all necessary parameters in ethereum compatible forms
@@ -45,6 +61,7 @@ type Config struct {
 	bridgeContract         common.Address
 	erc20HandlerContract   common.Address
 	erc721HandlerContract  common.Address
+	erc1155HandlerContract common.Address
 	genericHandlerContract common.Address
 	gasLimit               *big.Int
 	maxGasPrice            *big.Int
@@ -53,6 +70,15 @@ type Config struct {
 	startBlock             *big.Int
 	blockConfirmations     *big.Int
 	mainChainId            *big.Int
+	decimals               *decimals.Table     // Converts fungible amounts to the decimals of this chain, nil if unset
+	contractVersion        string              // Version of the bridge contract interface
+	genericCalls           *generic.Registry   // Decodes and validates the metadata of generic transfers, nil if unset
+	policies               *policy.Policies    // Filters deposits before they are routed, nil if unset
+	tiers                  confirmationTiers   // Confirmations required by large deposits, nil if unset
+	approvals              approval.Thresholds // Transfers above these amounts wait for an operator, nil if unset
+	limits                 *breaker.Limits     // Volume limits of the routes to this chain, nil if unset
+	pauseOnBreach          bool                // Pauses the bridge when a volume limit is exceeded
+	monitorVotes           bool                // Checks the votes of other relayers against the deposits
 }
 
 // parseChainConfig uses a core.ChainConfig to construct a corresponding Config
@@ -69,6 +95,7 @@ func parseChainConfig(chainCfg *core.ChainConfig) (*Config, error) {
 		bridgeContract:         utils.ZeroAddress,
 		erc20HandlerContract:   utils.ZeroAddress,
 		erc721HandlerContract:  utils.ZeroAddress,
+		erc1155HandlerContract: utils.ZeroAddress,
 		genericHandlerContract: utils.ZeroAddress,
 		gasLimit:               big.NewInt(DefaultGasLimit),
 		maxGasPrice:            big.NewInt(DefaultGasPrice),
@@ -77,6 +104,8 @@ func parseChainConfig(chainCfg *core.ChainConfig) (*Config, error) {
 		startBlock:             big.NewInt(0),
 		blockConfirmations:     big.NewInt(0),
 		mainChainId:            big.NewInt(0),
+		contractVersion:        DefaultContractVersion,
+		monitorVotes:           false,
 	}
 
 	if contract, ok := chainCfg.Opts[BridgeOpt]; ok && contract != "" {
@@ -92,6 +121,9 @@ func parseChainConfig(chainCfg *core.ChainConfig) (*Config, error) {
 	config.erc721HandlerContract = common.HexToAddress(chainCfg.Opts[Erc721HandlerOpt])
 	delete(chainCfg.Opts, Erc721HandlerOpt)
 
+	config.erc1155HandlerContract = common.HexToAddress(chainCfg.Opts[Erc1155HandlerOpt])
+	delete(chainCfg.Opts, Erc1155HandlerOpt)
+
 	config.genericHandlerContract = common.HexToAddress(chainCfg.Opts[GenericHandlerOpt])
 	delete(chainCfg.Opts, GenericHandlerOpt)
 
@@ -173,9 +205,100 @@ func parseChainConfig(chainCfg *core.ChainConfig) (*Config, error) {
 		return nil, fmt.Errorf("unable to parse %s", MainChainIdOpt)
 	}
 
+	if resourceDecimals, ok := chainCfg.Opts[ResourceDecimalsOpt]; ok && resourceDecimals != "" {
+		table, err := decimals.Parse(resourceDecimals)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse %s: %w", ResourceDecimalsOpt, err)
+		}
+		config.decimals = table
+		delete(chainCfg.Opts, ResourceDecimalsOpt)
+	}
+
+	if version, ok := chainCfg.Opts[ContractVersionOpt]; ok && version != "" {
+		if version != LegacyContractVersion && version != V2ContractVersion {
+			return nil, fmt.Errorf("unsupported %s: %s", ContractVersionOpt, version)
+		}
+		config.contractVersion = version
+		delete(chainCfg.Opts, ContractVersionOpt)
+	}
+
+	if path, ok := chainCfg.Opts[GenericCallsOpt]; ok && path != "" {
+		registry, err := generic.Load(path)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load %s: %w", GenericCallsOpt, err)
+		}
+		config.genericCalls = registry
+		delete(chainCfg.Opts, GenericCallsOpt)
+	}
+
+	if path, ok := chainCfg.Opts[DepositPoliciesOpt]; ok && path != "" {
+		policies, err := policy.Load(path)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load %s: %w", DepositPoliciesOpt, err)
+		}
+		config.policies = policies
+		delete(chainCfg.Opts, DepositPoliciesOpt)
+	}
+
+	if tiers, ok := chainCfg.Opts[ConfirmationTiersOpt]; ok && tiers != "" {
+		parsed, err := parseConfirmationTiers(tiers)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse %s: %w", ConfirmationTiersOpt, err)
+		}
+		config.tiers = parsed
+		delete(chainCfg.Opts, ConfirmationTiersOpt)
+	}
+
+	if thresholds, ok := chainCfg.Opts[ApprovalThresholdsOpt]; ok && thresholds != "" {
+		parsed, err := approval.ParseThresholds(thresholds)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse %s: %w", ApprovalThresholdsOpt, err)
+		}
+		config.approvals = parsed
+		delete(chainCfg.Opts, ApprovalThresholdsOpt)
+	}
+
+	if limits, ok := chainCfg.Opts[VolumeLimitsOpt]; ok && limits != "" {
+		parsed, err := breaker.ParseLimits(limits)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse %s: %w", VolumeLimitsOpt, err)
+		}
+		config.limits = parsed
+		delete(chainCfg.Opts, VolumeLimitsOpt)
+	}
+
+	if pause, ok := chainCfg.Opts[PauseOnBreachOpt]; ok {
+		parsed, err := parseBoolOpt(pause)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse %s: %w", PauseOnBreachOpt, err)
+		}
+		config.pauseOnBreach = parsed
+		delete(chainCfg.Opts, PauseOnBreachOpt)
+	}
+
+	if monitor, ok := chainCfg.Opts[MonitorVotesOpt]; ok {
+		parsed, err := parseBoolOpt(monitor)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse %s: %w", MonitorVotesOpt, err)
+		}
+		config.monitorVotes = parsed
+		delete(chainCfg.Opts, MonitorVotesOpt)
+	}
+
 	if len(chainCfg.Opts) != 0 {
 		return nil, fmt.Errorf("unknown Opts Encountered: %#v", chainCfg.Opts)
 	}
 
 	return config, nil
 }
+
+// parseBoolOpt parses the value of a boolean option, which must be "true" or "false"
+func parseBoolOpt(value string) (bool, error) {
+	switch value {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	}
+	return false, fmt.Errorf("expected true or false, got %q", value)
+}
@@ -4,11 +4,15 @@
 package ethereum
 
 import (
+	"fmt"
+	"io/ioutil"
 	"math/big"
+	"path/filepath"
 	"reflect"
 	"testing"
 
 	"github.com/centrifuge/chainbridge-utils/core"
+	"github.com/centrifuge/chainbridge-utils/msg"
 	"github.com/ethereum/go-ethereum/common"
 )
 
@@ -224,3 +228,198 @@ func TestExtraOpts(t *testing.T) {
 		t.Error("Config should not accept incorrect opts.")
 	}
 }
+
+func TestParseResourceDecimals(t *testing.T) {
+	rId := msg.ResourceId{31: 1}
+	input := core.ChainConfig{
+		Name:     "chain",
+		Id:       1,
+		Endpoint: "endpoint",
+		From:     "0x0",
+		Opts: map[string]string{
+			"bridge":           "0x1234",
+			"mainChainId":      "5",
+			"resourceDecimals": fmt.Sprintf("2/0x%x:12:18", rId),
+		},
+	}
+
+	out, err := parseChainConfig(&input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	amount, err := out.decimals.Convert(2, rId, big.NewInt(15), 256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if amount.Cmp(big.NewInt(15000000)) != 0 {
+		t.Fatalf("Got: %s Expected: %d", amount, 15000000)
+	}
+
+	input.Opts = map[string]string{
+		"bridge":           "0x1234",
+		"mainChainId":      "5",
+		"resourceDecimals": "0x01:12:18",
+	}
+	_, err = parseChainConfig(&input)
+	if err == nil {
+		t.Fatal("Config should not accept invalid resource decimals.")
+	}
+}
+
+func TestParseContractVersion(t *testing.T) {
+	input := core.ChainConfig{
+		Name:     "chain",
+		Id:       1,
+		Endpoint: "endpoint",
+		From:     "0x0",
+		Opts: map[string]string{
+			"bridge":      "0x1234",
+			"mainChainId": "5",
+		},
+	}
+
+	out, err := parseChainConfig(&input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.contractVersion != LegacyContractVersion {
+		t.Fatalf("Got: %s Expected: %s", out.contractVersion, LegacyContractVersion)
+	}
+
+	input.Opts = map[string]string{
+		"bridge":          "0x1234",
+		"mainChainId":     "5",
+		"contractVersion": "2",
+	}
+	out, err = parseChainConfig(&input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.contractVersion != V2ContractVersion {
+		t.Fatalf("Got: %s Expected: %s", out.contractVersion, V2ContractVersion)
+	}
+
+	input.Opts = map[string]string{
+		"bridge":          "0x1234",
+		"mainChainId":     "5",
+		"contractVersion": "3",
+	}
+	_, err = parseChainConfig(&input)
+	if err == nil {
+		t.Fatal("Config should not accept unsupported contract versions.")
+	}
+}
+
+func TestParseGenericCalls(t *testing.T) {
+	rId := msg.ResourceId{31: 1}
+	path := filepath.Join(t.TempDir(), "generic.json")
+	err := ioutil.WriteFile(path, []byte(fmt.Sprintf(`{"0x%x": {"selectors": ["0x12345678"], "enforce": true}}`, rId)), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	input := core.ChainConfig{
+		Name:     "chain",
+		Id:       1,
+		Endpoint: "endpoint",
+		From:     "0x0",
+		Opts: map[string]string{
+			"bridge":       "0x1234",
+			"mainChainId":  "5",
+			"genericCalls": path,
+		},
+	}
+
+	out, err := parseChainConfig(&input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rule, ok := out.genericCalls.Lookup(rId); !ok || !rule.Enforce {
+		t.Fatalf("unexpected rule: %#v (%t)", rule, ok)
+	}
+
+	input.Opts = map[string]string{
+		"bridge":       "0x1234",
+		"mainChainId":  "5",
+		"genericCalls": filepath.Join(t.TempDir(), "missing.json"),
+	}
+	_, err = parseChainConfig(&input)
+	if err == nil {
+		t.Fatal("Config should not accept missing generic calls files.")
+	}
+}
+
+func TestParsePauseOnBreach(t *testing.T) {
+	input := core.ChainConfig{
+		Name:     "chain",
+		Id:       1,
+		Endpoint: "endpoint",
+		From:     "0x0",
+		Opts: map[string]string{
+			"bridge":        "0x1234",
+			"mainChainId":   "5",
+			"pauseOnBreach": "true",
+		},
+	}
+
+	out, err := parseChainConfig(&input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !out.pauseOnBreach {
+		t.Fatal("Expected pauseOnBreach to be set")
+	}
+
+	input.Opts = map[string]string{
+		"bridge":        "0x1234",
+		"mainChainId":   "5",
+		"pauseOnBreach": "yes",
+	}
+	if _, err = parseChainConfig(&input); err == nil {
+		t.Fatal("Config should not accept values other than true or false.")
+	}
+}
+
+func TestParseMonitorVotes(t *testing.T) {
+	input := core.ChainConfig{
+		Name:     "chain",
+		Id:       1,
+		Endpoint: "endpoint",
+		From:     "0x0",
+		Opts: map[string]string{
+			"bridge":      "0x1234",
+			"mainChainId": "5",
+		},
+	}
+
+	out, err := parseChainConfig(&input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.monitorVotes {
+		t.Fatal("Expected votes not to be monitored by default")
+	}
+
+	input.Opts = map[string]string{
+		"bridge":       "0x1234",
+		"mainChainId":  "5",
+		"monitorVotes": "true",
+	}
+	out, err = parseChainConfig(&input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !out.monitorVotes {
+		t.Fatal("Expected the vote monitor to be enabled")
+	}
+
+	input.Opts = map[string]string{
+		"bridge":       "0x1234",
+		"mainChainId":  "5",
+		"monitorVotes": "off",
+	}
+	if _, err = parseChainConfig(&input); err == nil {
+		t.Fatal("Config should not accept values other than true or false.")
+	}
+}
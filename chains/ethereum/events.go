@@ -4,63 +4,120 @@
 package ethereum
 
 import (
+	"fmt"
+
+	"github.com/ChainSafe/ChainBridge/chains/payload"
 	"github.com/centrifuge/chainbridge-utils/msg"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
 )
 
-func (l *listener) handleErc20DepositedEvent(destId msg.ChainId, nonce msg.Nonce) (msg.Message, error) {
-	l.log.Info("Handling fungible deposit event", "dest", destId, "nonce", nonce)
+// invalidDepositData marks deposit data that cannot be decoded as an invalid payload, retrying cannot fix it
+func invalidDepositData(err error) error {
+	return fmt.Errorf("%w: %v", payload.ErrInvalidPayload, err)
+}
+
+func (l *listener) handleErc20DepositedEvent(dep depositEvent) (msg.Message, common.Address, error) {
+	l.log.Info("Handling fungible deposit event", "dest", dep.destId, "nonce", dep.nonce)
 
-	record, err := l.erc20HandlerContract.GetDepositRecord(&bind.CallOpts{From: l.conn.Keypair().CommonAddress()}, uint64(nonce), uint8(destId))
-	if err != nil {
-		l.log.Error("Error Unpacking ERC20 Deposit Record", "err", err)
-		return msg.Message{}, err
+	p := &payload.Fungible{}
+	sender := dep.sender
+	if dep.data != nil {
+		amount, recipient, err := ParseErc20ProposalData(dep.data)
+		if err != nil {
+			return msg.Message{}, common.Address{}, invalidDepositData(err)
+		}
+		p.Amount, p.Recipient = amount, recipient
+	} else {
+		record, err := l.erc20HandlerContract.GetDepositRecord(&bind.CallOpts{From: l.conn.Keypair().CommonAddress()}, uint64(dep.nonce), uint8(dep.destId))
+		if err != nil {
+			l.log.Error("Error Unpacking ERC20 Deposit Record", "err", err)
+			return msg.Message{}, common.Address{}, err
+		}
+		p.Amount, p.Recipient, sender = record.Amount, record.DestinationRecipientAddress, record.Depositer
 	}
 
-	return msg.NewFungibleTransfer(
-		l.cfg.id,
-		destId,
-		nonce,
-		record.Amount,
-		record.ResourceID,
-		record.DestinationRecipientAddress,
-	), nil
+	if err := p.Validate(); err != nil {
+		return msg.Message{}, common.Address{}, err
+	}
+	return p.Message(l.cfg.id, dep.destId, dep.nonce, dep.rId), sender, nil
 }
 
-func (l *listener) handleErc721DepositedEvent(destId msg.ChainId, nonce msg.Nonce) (msg.Message, error) {
+func (l *listener) handleErc721DepositedEvent(dep depositEvent) (msg.Message, common.Address, error) {
 	l.log.Info("Handling nonfungible deposit event")
 
-	record, err := l.erc721HandlerContract.GetDepositRecord(&bind.CallOpts{From: l.conn.Keypair().CommonAddress()}, uint64(nonce), uint8(destId))
-	if err != nil {
-		l.log.Error("Error Unpacking ERC721 Deposit Record", "err", err)
-		return msg.Message{}, err
+	p := &payload.NonFungible{}
+	sender := dep.sender
+	if dep.data != nil {
+		tokenId, recipient, metadata, err := ParseErc721ProposalData(dep.data)
+		if err != nil {
+			return msg.Message{}, common.Address{}, invalidDepositData(err)
+		}
+		p.TokenId, p.Recipient, p.Metadata = tokenId, recipient, metadata
+	} else {
+		record, err := l.erc721HandlerContract.GetDepositRecord(&bind.CallOpts{From: l.conn.Keypair().CommonAddress()}, uint64(dep.nonce), uint8(dep.destId))
+		if err != nil {
+			l.log.Error("Error Unpacking ERC721 Deposit Record", "err", err)
+			return msg.Message{}, common.Address{}, err
+		}
+		p.TokenId, p.Recipient, p.Metadata, sender = record.TokenID, record.DestinationRecipientAddress, record.MetaData, record.Depositer
+	}
+
+	if err := p.Validate(); err != nil {
+		return msg.Message{}, common.Address{}, err
+	}
+	return p.Message(l.cfg.id, dep.destId, dep.nonce, dep.rId), sender, nil
+}
+
+func (l *listener) handleErc1155DepositedEvent(dep depositEvent) (msg.Message, common.Address, error) {
+	l.log.Info("Handling semi-fungible deposit event")
+
+	p := &payload.SemiFungible{}
+	sender := dep.sender
+	if dep.data != nil {
+		tokenIds, amounts, recipient, transferData, err := ParseErc1155ProposalData(dep.data)
+		if err != nil {
+			return msg.Message{}, common.Address{}, invalidDepositData(err)
+		}
+		p.TokenIds, p.Amounts, p.Recipient, p.Data = tokenIds, amounts, recipient, transferData
+	} else {
+		record, err := l.erc1155HandlerContract.GetDepositRecord(&bind.CallOpts{From: l.conn.Keypair().CommonAddress()}, uint64(dep.nonce), uint8(dep.destId))
+		if err != nil {
+			l.log.Error("Error Unpacking ERC1155 Deposit Record", "err", err)
+			return msg.Message{}, common.Address{}, err
+		}
+		p.TokenIds, p.Amounts, p.Recipient, p.Data = record.TokenIDs, record.Amounts, record.DestinationRecipientAddress, record.TransferData
+		sender = record.Depositer
 	}
 
-	return msg.NewNonFungibleTransfer(
-		l.cfg.id,
-		destId,
-		nonce,
-		record.ResourceID,
-		record.TokenID,
-		record.DestinationRecipientAddress,
-		record.MetaData,
-	), nil
+	if err := p.Validate(); err != nil {
+		return msg.Message{}, common.Address{}, err
+	}
+	return p.Message(l.cfg.id, dep.destId, dep.nonce, dep.rId), sender, nil
 }
 
-func (l *listener) handleGenericDepositedEvent(destId msg.ChainId, nonce msg.Nonce) (msg.Message, error) {
+func (l *listener) handleGenericDepositedEvent(dep depositEvent) (msg.Message, common.Address, error) {
 	l.log.Info("Handling generic deposit event")
 
-	record, err := l.genericHandlerContract.GetDepositRecord(&bind.CallOpts{From: l.conn.Keypair().CommonAddress()}, uint64(nonce), uint8(destId))
-	if err != nil {
-		l.log.Error("Error Unpacking Generic Deposit Record", "err", err)
-		return msg.Message{}, nil
+	p := &payload.Generic{}
+	sender := dep.sender
+	if dep.data != nil {
+		metadata, err := ParseGenericProposalData(dep.data)
+		if err != nil {
+			return msg.Message{}, common.Address{}, invalidDepositData(err)
+		}
+		p.Metadata = metadata
+	} else {
+		record, err := l.genericHandlerContract.GetDepositRecord(&bind.CallOpts{From: l.conn.Keypair().CommonAddress()}, uint64(dep.nonce), uint8(dep.destId))
+		if err != nil {
+			l.log.Error("Error Unpacking Generic Deposit Record", "err", err)
+			return msg.Message{}, common.Address{}, nil
+		}
+		p.Metadata, sender = record.MetaData[:], record.Depositer
 	}
 
-	return msg.NewGenericTransfer(
-		l.cfg.id,
-		destId,
-		nonce,
-		record.ResourceID,
-		record.MetaData[:],
-	), nil
+	if err := p.Validate(); err != nil {
+		return msg.Message{}, common.Address{}, err
+	}
+	return p.Message(l.cfg.id, dep.destId, dep.nonce, dep.rId), sender, nil
 }
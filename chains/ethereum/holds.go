@@ -0,0 +1,140 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package ethereum
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/centrifuge/chainbridge-utils/msg"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const HoldsPathPostfix = ".chainbridge/holds"
+
+// hold is a deposit waiting for the confirmations of its tier. Only the location of the deposit is stored, the
+// deposit is read again from the chain once it is released.
+type hold struct {
+	Block   *big.Int    `json:"block"`   // Block of the deposit
+	Release *big.Int    `json:"release"` // Block at which the deposit has the required confirmations
+	DestId  msg.ChainId `json:"destId"`
+	Nonce   msg.Nonce   `json:"nonce"`
+}
+
+// holdStore persists the held deposits of a chain, so they are released after a restart
+type holdStore struct {
+	path     string // Path excluding filename
+	fullPath string
+	holds    []hold
+	lock     sync.Mutex
+	gauge    prometheus.Gauge // Nil if metrics are disabled
+}
+
+// newHoldStore loads the held deposits of the chain/relayer pair from disk
+func newHoldStore(path string, chain msg.ChainId, relayer string) (*holdStore, error) {
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		path = filepath.Join(home, HoldsPathPostfix)
+	}
+
+	s := &holdStore{
+		path:     path,
+		fullPath: filepath.Join(path, fmt.Sprintf("%s-%d.holds", relayer, chain)),
+	}
+	data, err := ioutil.ReadFile(s.fullPath)
+	if os.IsNotExist(err) {
+		return s, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, &s.holds); err != nil {
+		return nil, fmt.Errorf("invalid holds file %s: %w", s.fullPath, err)
+	}
+	return s, nil
+}
+
+// registerMetrics creates and registers the gauge of held deposits
+func (s *holdStore) registerMetrics(chain string) {
+	s.gauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: fmt.Sprintf("%s_held_deposits", chain),
+		Help: "Number of deposits waiting for the confirmations of their tier",
+	})
+	prometheus.MustRegister(s.gauge)
+	s.gauge.Set(float64(len(s.holds)))
+}
+
+// add holds the deposit until the release block
+func (s *holdStore) add(h hold) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, existing := range s.holds {
+		if existing.DestId == h.DestId && existing.Nonce == h.Nonce {
+			return nil
+		}
+	}
+	return s.write(append(s.holds, h))
+}
+
+// due returns the deposits released by the latest block
+func (s *holdStore) due(latest *big.Int) []hold {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var res []hold
+	for _, h := range s.holds {
+		if latest.Cmp(h.Release) >= 0 {
+			res = append(res, h)
+		}
+	}
+	return res
+}
+
+// remove releases the deposit
+func (s *holdStore) remove(h hold) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	holds := make([]hold, 0, len(s.holds))
+	for _, existing := range s.holds {
+		if existing.DestId != h.DestId || existing.Nonce != h.Nonce {
+			holds = append(holds, existing)
+		}
+	}
+	return s.write(holds)
+}
+
+// write replaces the holds on disk, the holds in memory are only updated if the write succeeds
+func (s *holdStore) write(holds []hold) error {
+	data, err := json.Marshal(holds)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(s.path, os.ModePerm); err != nil {
+		return err
+	}
+	// Write to a temporary file first, so a crash cannot leave a partial file
+	tmp := s.fullPath + ".tmp"
+	if err = ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	if err = os.Rename(tmp, s.fullPath); err != nil {
+		return err
+	}
+
+	s.holds = holds
+	if s.gauge != nil {
+		s.gauge.Set(float64(len(holds)))
+	}
+	return nil
+}
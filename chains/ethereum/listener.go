@@ -10,11 +10,13 @@ import (
 	"math/big"
 	"time"
 
-	"github.com/ChainSafe/ChainBridge/bindings/Bridge"
+	"github.com/ChainSafe/ChainBridge/bindings/ERC1155Handler"
 	"github.com/ChainSafe/ChainBridge/bindings/ERC20Handler"
 	"github.com/ChainSafe/ChainBridge/bindings/ERC721Handler"
 	"github.com/ChainSafe/ChainBridge/bindings/GenericHandler"
 	"github.com/ChainSafe/ChainBridge/chains"
+	"github.com/ChainSafe/ChainBridge/chains/deadletter"
+	"github.com/ChainSafe/ChainBridge/chains/payload"
 	utils "github.com/ChainSafe/ChainBridge/shared/ethereum"
 	"github.com/ChainSafe/log15"
 	"github.com/centrifuge/chainbridge-utils/blockstore"
@@ -33,9 +35,10 @@ type listener struct {
 	cfg                    Config
 	conn                   Connection
 	router                 chains.Router
-	bridgeContract         *Bridge.Bridge // instance of bound bridge contract
+	bridge                 bridgeAdapter // instance of bound bridge contract
 	erc20HandlerContract   *ERC20Handler.ERC20Handler
 	erc721HandlerContract  *ERC721Handler.ERC721Handler
+	erc1155HandlerContract *ERC1155Handler.ERC1155Handler
 	genericHandlerContract *GenericHandler.GenericHandler
 	log                    log15.Logger
 	blockstore             blockstore.Blockstorer
@@ -44,10 +47,13 @@ type listener struct {
 	latestBlock            metrics.LatestBlock
 	metrics                *metrics.ChainMetrics
 	blockConfirmations     *big.Int
+	deadLetters            deadletter.Storer // Records deposits rejected by a policy
+	holds                  *holdStore        // Deposits waiting for the confirmations of their tier, nil if no tiers are set
+	votes                  *voteMonitor      // Checks the votes of other relayers, nil if disabled
 }
 
 // NewListener creates and returns a listener
-func NewListener(conn Connection, cfg *Config, log log15.Logger, bs blockstore.Blockstorer, stop <-chan int, sysErr chan<- error, m *metrics.ChainMetrics) *listener {
+func NewListener(conn Connection, cfg *Config, log log15.Logger, bs blockstore.Blockstorer, stop <-chan int, sysErr chan<- error, m *metrics.ChainMetrics, dl deadletter.Storer) *listener {
 	return &listener{
 		cfg:                *cfg,
 		conn:               conn,
@@ -58,14 +64,16 @@ func NewListener(conn Connection, cfg *Config, log log15.Logger, bs blockstore.B
 		latestBlock:        metrics.LatestBlock{LastUpdated: time.Now()},
 		metrics:            m,
 		blockConfirmations: cfg.blockConfirmations,
+		deadLetters:        dl,
 	}
 }
 
 // setContracts sets the listener with the appropriate contracts
-func (l *listener) setContracts(bridge *Bridge.Bridge, erc20Handler *ERC20Handler.ERC20Handler, erc721Handler *ERC721Handler.ERC721Handler, genericHandler *GenericHandler.GenericHandler) {
-	l.bridgeContract = bridge
+func (l *listener) setContracts(bridge bridgeAdapter, erc20Handler *ERC20Handler.ERC20Handler, erc721Handler *ERC721Handler.ERC721Handler, erc1155Handler *ERC1155Handler.ERC1155Handler, genericHandler *GenericHandler.GenericHandler) {
+	l.bridge = bridge
 	l.erc20HandlerContract = erc20Handler
 	l.erc721HandlerContract = erc721Handler
+	l.erc1155HandlerContract = erc1155Handler
 	l.genericHandlerContract = genericHandler
 }
 
@@ -119,6 +127,8 @@ func (l *listener) pollBlocks() error {
 				l.metrics.LatestKnownBlock.Set(float64(latestBlock.Int64()))
 			}
 
+			l.releaseHolds(latestBlock)
+
 			// Sleep if the difference is less than BlockDelay; (latest - current) < BlockDelay
 			if big.NewInt(0).Sub(latestBlock, currentBlock).Cmp(l.blockConfirmations) == -1 {
 				l.log.Debug("Block not ready, will retry", "target", currentBlock, "latest", latestBlock)
@@ -126,6 +136,9 @@ func (l *listener) pollBlocks() error {
 				continue
 			}
 
+			// Checked before the deposits, as checking a vote twice when the block is retried has no effect
+			l.checkVotes(currentBlock)
+
 			// Parse out events
 			err = l.getDepositEventsForBlock(currentBlock)
 			if err != nil {
@@ -155,15 +168,27 @@ func (l *listener) pollBlocks() error {
 	}
 }
 
+// checkVotes passes the votes cast in the block to the vote monitor. Votes are only monitored, so a failure is
+// logged instead of holding up the deposits of the block.
+func (l *listener) checkVotes(block *big.Int) {
+	if l.votes == nil {
+		return
+	}
+	votes, err := l.bridge.proposalVotes(block)
+	if err != nil {
+		l.log.Error("Failed to fetch votes of block, they are not checked", "block", block, "err", err)
+		return
+	}
+	l.votes.observe(block.Uint64(), votes)
+}
+
 // getDepositEventsForBlock looks for the deposit event in the latest block
 func (l *listener) getDepositEventsForBlock(latestBlock *big.Int) error {
 	l.log.Debug("Querying block for deposit events", "block", latestBlock)
-	query := buildQuery(l.cfg.bridgeContract, utils.Deposit, latestBlock, latestBlock)
 
-	// querying for logs
-	logs, err := l.conn.Client().FilterLogs(context.Background(), query)
+	deposits, err := l.bridge.deposits(latestBlock)
 	if err != nil {
-		return fmt.Errorf("unable to Filter Logs: %w", err)
+		return err
 	}
 
 	mainChainId, err := l.conn.Client().ChainID(context.Background())
@@ -175,40 +200,123 @@ func (l *listener) getDepositEventsForBlock(latestBlock *big.Int) error {
 		panic(fmt.Errorf("chainId (%d) doesnt match with config defined mainChainId (%d)", mainChainId, l.cfg.mainChainId))
 	}
 
-	// read through the log events and handle their deposit event if handler is recognized
-	for _, log := range logs {
-		var m msg.Message
-		destId := msg.ChainId(log.Topics[1].Big().Uint64())
-		rId := msg.ResourceIdFromSlice(log.Topics[2].Bytes())
-		nonce := msg.Nonce(log.Topics[3].Big().Uint64())
-
-		addr, err := l.bridgeContract.ResourceIDToHandlerAddress(&bind.CallOpts{From: l.conn.Keypair().CommonAddress()}, rId)
+	// read through the deposits and handle them if the handler is recognized
+	for _, dep := range deposits {
+		err = l.handleDeposit(latestBlock, dep, true)
 		if err != nil {
-			return fmt.Errorf("failed to get handler from resource ID %x", rId)
+			return err
 		}
+	}
 
-		if addr == l.cfg.erc20HandlerContract {
-			m, err = l.handleErc20DepositedEvent(destId, nonce)
-		} else if addr == l.cfg.erc721HandlerContract {
-			m, err = l.handleErc721DepositedEvent(destId, nonce)
-		} else if addr == l.cfg.genericHandlerContract {
-			m, err = l.handleGenericDepositedEvent(destId, nonce)
-		} else {
-			l.log.Error("event has unrecognized handler", "handler", addr.Hex())
-			return nil
+	return nil
+}
+
+// handleDeposit constructs the message of the deposit and routes it. If checkTiers is set, deposits that require
+// more confirmations than the block has are held instead.
+func (l *listener) handleDeposit(block *big.Int, dep depositEvent, checkTiers bool) error {
+	var m msg.Message
+	var sender ethcommon.Address
+	addr, err := l.bridge.handlerAddress(&bind.CallOpts{From: l.conn.Keypair().CommonAddress()}, dep.rId)
+	if err != nil {
+		return fmt.Errorf("failed to get handler from resource ID %x", dep.rId)
+	}
+
+	if addr == l.cfg.erc20HandlerContract {
+		m, sender, err = l.handleErc20DepositedEvent(dep)
+	} else if addr == l.cfg.erc721HandlerContract {
+		m, sender, err = l.handleErc721DepositedEvent(dep)
+	} else if l.cfg.erc1155HandlerContract != utils.ZeroAddress && addr == l.cfg.erc1155HandlerContract {
+		m, sender, err = l.handleErc1155DepositedEvent(dep)
+	} else if addr == l.cfg.genericHandlerContract {
+		m, sender, err = l.handleGenericDepositedEvent(dep)
+	} else {
+		l.log.Error("event has unrecognized handler", "handler", addr.Hex())
+		return nil
+	}
+
+	if errors.Is(err, payload.ErrInvalidPayload) {
+		// Retrying cannot fix the deposit record, skip the deposit
+		l.log.Error("Skipping deposit with invalid payload", "dest", dep.destId, "nonce", dep.nonce, "err", err)
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if err = l.cfg.policies.Check(m, sender.Bytes()); err != nil {
+		l.log.Warn("Deposit rejected by policy, not relaying", "dest", dep.destId, "nonce", dep.nonce, "err", err)
+		if err = l.deadLetters.StoreMessage(m, err); err != nil {
+			l.log.Error("Failed to record rejected deposit", "nonce", dep.nonce, "err", err)
 		}
+		return nil
+	}
 
+	if checkTiers {
+		if confirmations := l.requiredConfirmations(m); confirmations != nil && confirmations.Cmp(l.blockConfirmations) > 0 {
+			h := hold{
+				Block:   new(big.Int).Set(block),
+				Release: new(big.Int).Add(block, confirmations),
+				DestId:  dep.destId,
+				Nonce:   dep.nonce,
+			}
+			l.log.Info("Holding deposit until its tier is confirmed", "dest", dep.destId, "nonce", dep.nonce, "release", h.Release)
+			return l.holds.add(h)
+		}
+	}
+
+	err = l.router.Send(m)
+	if err != nil {
+		l.log.Error("subscription error: failed to route message", "err", err)
+	}
+	return nil
+}
+
+// requiredConfirmations returns the confirmations required by the tier of the deposit, nil if none applies
+func (l *listener) requiredConfirmations(m msg.Message) *big.Int {
+	if l.holds == nil || l.cfg.tiers == nil {
+		return nil
+	}
+	amount, err := payload.Amount(m)
+	if err != nil {
+		return nil
+	}
+	return l.cfg.tiers.confirmations(m.ResourceId, amount)
+}
+
+// releaseHolds routes the held deposits that have reached the confirmations of their tier. The deposits are read
+// again, so deposits that were removed by a reorg are dropped. Failed releases are retried on the next poll.
+func (l *listener) releaseHolds(latestBlock *big.Int) {
+	if l.holds == nil {
+		return
+	}
+	for _, h := range l.holds.due(latestBlock) {
+		deposits, err := l.bridge.deposits(h.Block)
 		if err != nil {
-			return err
+			l.log.Error("Failed to get held deposit", "block", h.Block, "nonce", h.Nonce, "err", err)
+			continue
 		}
 
-		err = l.router.Send(m)
+		found := false
+		for _, dep := range deposits {
+			if dep.destId != h.DestId || dep.nonce != h.Nonce {
+				continue
+			}
+			found = true
+			err = l.handleDeposit(h.Block, dep, false)
+		}
 		if err != nil {
-			l.log.Error("subscription error: failed to route message", "err", err)
+			l.log.Error("Failed to release held deposit", "dest", h.DestId, "nonce", h.Nonce, "err", err)
+			continue
+		}
+		if !found {
+			l.log.Warn("Held deposit no longer exists, dropping it", "block", h.Block, "dest", h.DestId, "nonce", h.Nonce)
+		} else {
+			l.log.Info("Released held deposit", "dest", h.DestId, "nonce", h.Nonce)
 		}
-	}
 
-	return nil
+		if err = l.holds.remove(h); err != nil {
+			l.log.Error("Failed to remove released deposit", "dest", h.DestId, "nonce", h.Nonce, "err", err)
+		}
+	}
 }
 
 // buildQuery constructs a query for the bridgeContract by hashing sig to get the event topic
@@ -10,10 +10,10 @@ import (
 	"testing"
 	"time"
 
-	"github.com/ChainSafe/ChainBridge/bindings/Bridge"
 	"github.com/ChainSafe/ChainBridge/bindings/ERC20Handler"
 	"github.com/ChainSafe/ChainBridge/bindings/ERC721Handler"
 	"github.com/ChainSafe/ChainBridge/bindings/GenericHandler"
+	"github.com/ChainSafe/ChainBridge/chains/deadletter"
 	utils "github.com/ChainSafe/ChainBridge/shared/ethereum"
 	ethtest "github.com/ChainSafe/ChainBridge/shared/ethereum/testing"
 	"github.com/ChainSafe/log15"
@@ -47,7 +47,7 @@ func createTestListener(t *testing.T, config *Config, contracts *utils.DeployedC
 	}
 	newConfig.startBlock = latestBlock
 
-	bridgeContract, err := Bridge.NewBridge(newConfig.bridgeContract, conn.Client())
+	bridgeContract, err := newLegacyBridge(newConfig.bridgeContract, conn.Client())
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -65,8 +65,8 @@ func createTestListener(t *testing.T, config *Config, contracts *utils.DeployedC
 	}
 
 	router := &MockRouter{msgs: make(chan msg.Message)}
-	listener := NewListener(conn, &newConfig, TestLogger, &blockstore.EmptyStore{}, stop, sysErr, nil)
-	listener.setContracts(bridgeContract, erc20HandlerContract, erc721HandlerContract, genericHandlerContract)
+	listener := NewListener(conn, &newConfig, TestLogger, &blockstore.EmptyStore{}, stop, sysErr, nil, &deadletter.EmptyStore{})
+	listener.setContracts(bridgeContract, erc20HandlerContract, erc721HandlerContract, nil, genericHandlerContract)
 	listener.setRouter(router)
 	// Start the listener
 	err = listener.start()
@@ -137,7 +137,7 @@ func TestListener_Erc20DepositedEvent(t *testing.T) {
 	// Create an ERC20 Deposit
 	createErc20Deposit(
 		t,
-		l.bridgeContract,
+		l.bridge.(*legacyBridge).contract,
 		client,
 		resourceId,
 
@@ -159,7 +159,7 @@ func TestListener_Erc20DepositedEvent(t *testing.T) {
 	)
 	createErc20Deposit(
 		t,
-		l.bridgeContract,
+		l.bridge.(*legacyBridge).contract,
 		client,
 		resourceId,
 
@@ -207,7 +207,7 @@ func TestListener_Erc721DepositedEvent(t *testing.T) {
 	// Create an ERC20 Deposit
 	createErc721Deposit(
 		t,
-		l.bridgeContract,
+		l.bridge.(*legacyBridge).contract,
 		client,
 		resourceId,
 
@@ -247,7 +247,7 @@ func TestListener_GenericDepositedEvent(t *testing.T) {
 	// Create an ERC20 Deposit
 	createGenericDeposit(
 		t,
-		l.bridgeContract,
+		l.bridge.(*legacyBridge).contract,
 		client,
 		resourceId,
 
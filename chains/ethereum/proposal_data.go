@@ -4,8 +4,11 @@
 package ethereum
 
 import (
+	"errors"
+	"fmt"
 	"math/big"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/math"
 )
@@ -36,6 +39,24 @@ func ConstructErc721ProposalData(tokenId []byte, recipient []byte, metadata []by
 	return data
 }
 
+// erc1155ProposalArgs are the arguments of the ERC1155 handler's proposal data
+var erc1155ProposalArgs = func() abi.Arguments {
+	uint256Array, _ := abi.NewType("uint256[]", "", nil)
+	bytesType, _ := abi.NewType("bytes", "", nil)
+	return abi.Arguments{
+		{Name: "tokenIDs", Type: uint256Array},
+		{Name: "amounts", Type: uint256Array},
+		{Name: "recipient", Type: bytesType},
+		{Name: "transferData", Type: bytesType},
+	}
+}()
+
+// ConstructErc1155ProposalData returns the bytes to construct a proposal suitable for Erc1155. Unlike the other
+// proposals, the data is ABI encoded as (uint256[] tokenIDs, uint256[] amounts, bytes recipient, bytes transferData).
+func ConstructErc1155ProposalData(tokenIds []*big.Int, amounts []*big.Int, recipient []byte, transferData []byte) ([]byte, error) {
+	return erc1155ProposalArgs.Pack(tokenIds, amounts, recipient, transferData)
+}
+
 // constructGenericProposalData returns the bytes to construct a generic proposal
 func ConstructGenericProposalData(metadata []byte) []byte {
 	var data []byte
@@ -45,3 +66,68 @@ func ConstructGenericProposalData(metadata []byte) []byte {
 	data = append(data, metadata...)                             // metadata ([]byte)
 	return data
 }
+
+// ErrInvalidProposalData is returned if proposal data cannot be decoded
+var ErrInvalidProposalData = errors.New("invalid proposal data")
+
+// readBytes reads a uint256 length at the offset and the bytes following it. Returns the bytes and the offset after them.
+func readBytes(data []byte, offset int) ([]byte, int, error) {
+	if len(data) < offset+32 {
+		return nil, 0, fmt.Errorf("%w: missing length at offset %d", ErrInvalidProposalData, offset)
+	}
+	length := big.NewInt(0).SetBytes(data[offset : offset+32])
+	offset += 32
+	if !length.IsInt64() || length.Int64() > int64(len(data)-offset) {
+		return nil, 0, fmt.Errorf("%w: length %s exceeds data at offset %d", ErrInvalidProposalData, length, offset)
+	}
+	end := offset + int(length.Int64())
+	return data[offset:end], end, nil
+}
+
+// ParseErc20ProposalData decodes data constructed with ConstructErc20ProposalData
+func ParseErc20ProposalData(data []byte) (amount *big.Int, recipient []byte, err error) {
+	if len(data) < 32 {
+		return nil, nil, fmt.Errorf("%w: missing amount", ErrInvalidProposalData)
+	}
+	amount = big.NewInt(0).SetBytes(data[:32])
+	recipient, _, err = readBytes(data, 32)
+	if err != nil {
+		return nil, nil, err
+	}
+	return amount, recipient, nil
+}
+
+// ParseErc721ProposalData decodes data constructed with ConstructErc721ProposalData. The metadata may be omitted.
+func ParseErc721ProposalData(data []byte) (tokenId *big.Int, recipient []byte, metadata []byte, err error) {
+	if len(data) < 32 {
+		return nil, nil, nil, fmt.Errorf("%w: missing token ID", ErrInvalidProposalData)
+	}
+	tokenId = big.NewInt(0).SetBytes(data[:32])
+	recipient, offset, err := readBytes(data, 32)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	metadata = []byte{}
+	if offset < len(data) {
+		metadata, _, err = readBytes(data, offset)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	return tokenId, recipient, metadata, nil
+}
+
+// ParseErc1155ProposalData decodes data constructed with ConstructErc1155ProposalData
+func ParseErc1155ProposalData(data []byte) (tokenIds []*big.Int, amounts []*big.Int, recipient []byte, transferData []byte, err error) {
+	values, err := erc1155ProposalArgs.Unpack(data)
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("%w: %v", ErrInvalidProposalData, err)
+	}
+	return values[0].([]*big.Int), values[1].([]*big.Int), values[2].([]byte), values[3].([]byte), nil
+}
+
+// ParseGenericProposalData decodes data constructed with ConstructGenericProposalData
+func ParseGenericProposalData(data []byte) ([]byte, error) {
+	metadata, _, err := readBytes(data, 0)
+	return metadata, err
+}
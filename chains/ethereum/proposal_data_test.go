@@ -0,0 +1,86 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package ethereum
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestConstructErc1155ProposalData(t *testing.T) {
+	tokenIds := []*big.Int{big.NewInt(1), big.NewInt(42)}
+	amounts := []*big.Int{big.NewInt(100), big.NewInt(1)}
+	recipient := common.HexToAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed").Bytes()
+	transferData := []byte{0xde, 0xad}
+
+	data, err := ConstructErc1155ProposalData(tokenIds, amounts, recipient, transferData)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The handler decodes the data with abi.decode(data, (uint[], uint[], bytes, bytes))
+	values, err := erc1155ProposalArgs.Unpack(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []interface{}{tokenIds, amounts, recipient, transferData}
+	if !reflect.DeepEqual(values, expected) {
+		t.Fatalf("Got: %v Expected: %v", values, expected)
+	}
+}
+
+func TestParseProposalData(t *testing.T) {
+	recipient := common.HexToAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed").Bytes()
+	metadata := []byte{0xde, 0xad, 0xbe, 0xef}
+
+	amount, rec, err := ParseErc20ProposalData(ConstructErc20ProposalData(big.NewInt(100).Bytes(), recipient))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if amount.Int64() != 100 || !bytes.Equal(rec, recipient) {
+		t.Fatalf("Got: %s/%x Expected: 100/%x", amount, rec, recipient)
+	}
+
+	tokenId, rec, meta, err := ParseErc721ProposalData(ConstructErc721ProposalData(big.NewInt(42).Bytes(), recipient, metadata))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tokenId.Int64() != 42 || !bytes.Equal(rec, recipient) || !bytes.Equal(meta, metadata) {
+		t.Fatalf("Got: %s/%x/%x Expected: 42/%x/%x", tokenId, rec, meta, recipient, metadata)
+	}
+
+	// The metadata may be omitted
+	_, _, meta, err = ParseErc721ProposalData(ConstructErc20ProposalData(big.NewInt(42).Bytes(), recipient))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(meta) != 0 {
+		t.Fatalf("Got: %x Expected no metadata", meta)
+	}
+
+	meta, err = ParseGenericProposalData(ConstructGenericProposalData(metadata))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(meta, metadata) {
+		t.Fatalf("Got: %x Expected: %x", meta, metadata)
+	}
+
+	// Lengths exceeding the data are rejected
+	data := ConstructGenericProposalData(metadata)
+	if _, err = ParseGenericProposalData(data[:len(data)-1]); !errors.Is(err, ErrInvalidProposalData) {
+		t.Fatalf("Got: %v Expected: %v", err, ErrInvalidProposalData)
+	}
+	if _, _, err = ParseErc20ProposalData(data[:16]); !errors.Is(err, ErrInvalidProposalData) {
+		t.Fatalf("Got: %v Expected: %v", err, ErrInvalidProposalData)
+	}
+	if _, _, _, _, err = ParseErc1155ProposalData(metadata); !errors.Is(err, ErrInvalidProposalData) {
+		t.Fatalf("Got: %v Expected: %v", err, ErrInvalidProposalData)
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package ethereum
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/centrifuge/chainbridge-utils/msg"
+)
+
+// confirmationTier requires deposits of at least the threshold to wait for the confirmations
+type confirmationTier struct {
+	threshold     *big.Int
+	confirmations *big.Int
+}
+
+// confirmationTiers holds the tiers of each resource, sorted by threshold
+type confirmationTiers map[msg.ResourceId][]confirmationTier
+
+// parseConfirmationTiers parses a comma separated list of entries in the form <resourceId>:<amount>:<confirmations>
+func parseConfirmationTiers(s string) (confirmationTiers, error) {
+	tiers := make(confirmationTiers)
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.Split(entry, ":")
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("invalid tier %q: expected <resourceId>:<amount>:<confirmations>", entry)
+		}
+		rIdBytes, err := hex.DecodeString(strings.TrimPrefix(parts[0], "0x"))
+		if err != nil || len(rIdBytes) != 32 {
+			return nil, fmt.Errorf("invalid tier %q: invalid resource ID", entry)
+		}
+		threshold, ok := new(big.Int).SetString(parts[1], 10)
+		if !ok || threshold.Sign() < 0 {
+			return nil, fmt.Errorf("invalid tier %q: invalid amount", entry)
+		}
+		confirmations, ok := new(big.Int).SetString(parts[2], 10)
+		if !ok || confirmations.Sign() < 0 {
+			return nil, fmt.Errorf("invalid tier %q: invalid confirmations", entry)
+		}
+		rId := msg.ResourceIdFromSlice(rIdBytes)
+		tiers[rId] = append(tiers[rId], confirmationTier{threshold: threshold, confirmations: confirmations})
+	}
+	for rId := range tiers {
+		t := tiers[rId]
+		sort.Slice(t, func(i, j int) bool { return t[i].threshold.Cmp(t[j].threshold) < 0 })
+		for i := 1; i < len(t); i++ {
+			if t[i].threshold.Cmp(t[i-1].threshold) == 0 {
+				return nil, fmt.Errorf("duplicate tier %s of resource %x", t[i].threshold, rId)
+			}
+		}
+	}
+	return tiers, nil
+}
+
+// confirmations returns the confirmations required by the highest tier the amount reaches, nil if it reaches none
+func (t confirmationTiers) confirmations(rId msg.ResourceId, amount *big.Int) *big.Int {
+	var res *big.Int
+	for _, tier := range t[rId] {
+		if amount.Cmp(tier.threshold) < 0 {
+			break
+		}
+		res = tier.confirmations
+	}
+	return res
+}
@@ -0,0 +1,92 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package ethereum
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/chainbridge-utils/msg"
+)
+
+func TestConfirmationTiers(t *testing.T) {
+	token := msg.ResourceId{31: 1}
+	tiers, err := parseConfirmationTiers(fmt.Sprintf("0x%x:100000:50, 0x%x:1000:20", token, token))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		rId      msg.ResourceId
+		amount   int64
+		expected *big.Int
+	}{
+		{token, 999, nil},
+		{token, 1000, big.NewInt(20)},
+		{token, 99999, big.NewInt(20)},
+		{token, 100000, big.NewInt(50)},
+		{msg.ResourceId{31: 2}, 100000, nil},
+	}
+	for _, tc := range testCases {
+		res := tiers.confirmations(tc.rId, big.NewInt(tc.amount))
+		if (res == nil) != (tc.expected == nil) || (res != nil && res.Cmp(tc.expected) != 0) {
+			t.Errorf("amount %d: Got: %v Expected: %v", tc.amount, res, tc.expected)
+		}
+	}
+
+	invalid := []string{
+		"0x01:1000:20",
+		fmt.Sprintf("0x%x:1000", token),
+		fmt.Sprintf("0x%x:-1:20", token),
+		fmt.Sprintf("0x%x:1000:x", token),
+		fmt.Sprintf("0x%x:1000:20,0x%x:1000:30", token, token),
+	}
+	for _, s := range invalid {
+		if _, err := parseConfirmationTiers(s); err == nil {
+			t.Errorf("expected error for %s", s)
+		}
+	}
+}
+
+func TestHoldStore(t *testing.T) {
+	dir := t.TempDir()
+	s, err := newHoldStore(dir, 1, "relayer")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first := hold{Block: big.NewInt(10), Release: big.NewInt(30), DestId: 2, Nonce: 1}
+	second := hold{Block: big.NewInt(11), Release: big.NewInt(61), DestId: 2, Nonce: 2}
+	for _, h := range []hold{first, second, first} {
+		if err = s.add(h); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Holds are loaded again after a restart
+	s, err = newHoldStore(dir, 1, "relayer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if due := s.due(big.NewInt(29)); len(due) != 0 {
+		t.Fatalf("Got: %v Expected no due holds", due)
+	}
+	due := s.due(big.NewInt(30))
+	if len(due) != 1 || due[0].Nonce != first.Nonce {
+		t.Fatalf("Got: %v Expected: %v", due, first)
+	}
+
+	if err = s.remove(first); err != nil {
+		t.Fatal(err)
+	}
+	s, err = newHoldStore(dir, 1, "relayer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	due = s.due(big.NewInt(100))
+	if len(due) != 1 || due[0].Nonce != second.Nonce || due[0].Release.Cmp(second.Release) != 0 {
+		t.Fatalf("Got: %v Expected: %v", due, second)
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package ethereum
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ChainSafe/log15"
+	"github.com/centrifuge/chainbridge-utils/core"
+	"github.com/centrifuge/chainbridge-utils/msg"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Number of blocks a vote waits for the deposit it is checked against, and a data hash waits for votes
+const VoteWatchLimit = 1000
+
+var _ core.Writer = &voteMonitor{}
+
+type voteKey struct {
+	source msg.ChainId
+	nonce  msg.Nonce
+}
+
+// expectedHash is the data hash this relayer computed for a proposal, and the relayers whose votes were checked
+type expectedHash struct {
+	hash    [32]byte
+	err     error // Set if the data hash could not be computed, votes cannot be checked
+	block   uint64
+	checked map[common.Address]bool
+}
+
+// unverifiedVote is a vote seen before the deposit of its proposal was routed to this chain
+type unverifiedVote struct {
+	vote  voteEvent
+	block uint64
+}
+
+// voteMonitor checks the votes of other relayers against the data hash this relayer computes from the source
+// deposit. A vote for a different data hash means the relayer voted for data that was not deposited.
+//
+// The monitor resolves the messages routed to the chain before passing them on, so the data hash of messages
+// waiting for approval or held by a circuit breaker is known.
+type voteMonitor struct {
+	next        core.Writer
+	dataHash    func(m msg.Message) ([]byte, [32]byte, error) // Constructs the proposal data of a message
+	relayer     common.Address                                // Votes of this relayer are not checked
+	log         log15.Logger
+	lock        sync.Mutex
+	expected    map[voteKey]*expectedHash
+	unverified  map[voteKey][]unverifiedVote
+	latestBlock uint64
+	mismatches  prometheus.Counter // Nil if metrics are disabled
+	unchecked   prometheus.Counter // Nil if metrics are disabled
+}
+
+func newVoteMonitor(next core.Writer, dataHash func(m msg.Message) ([]byte, [32]byte, error), relayer common.Address, log log15.Logger) *voteMonitor {
+	return &voteMonitor{
+		next:       next,
+		dataHash:   dataHash,
+		relayer:    relayer,
+		log:        log,
+		expected:   make(map[voteKey]*expectedHash),
+		unverified: make(map[voteKey][]unverifiedVote),
+	}
+}
+
+// registerMetrics creates and registers the counters of divergent votes and votes that could not be checked
+func (v *voteMonitor) registerMetrics(chain string) {
+	v.mismatches = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: fmt.Sprintf("%s_vote_mismatches", chain),
+		Help: "Number of votes of other relayers for a data hash that differs from the one computed from the deposit",
+	})
+	v.unchecked = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: fmt.Sprintf("%s_unchecked_votes", chain),
+		Help: "Number of votes of other relayers that could not be checked against a deposit",
+	})
+	prometheus.MustRegister(v.mismatches)
+	prometheus.MustRegister(v.unchecked)
+}
+
+// ResolveMessage records the data hash of the message and checks any votes already seen for it, then passes the
+// message on. Messages whose data hash cannot be computed are recorded too, so votes for them raise an alert.
+func (v *voteMonitor) ResolveMessage(m msg.Message) bool {
+	_, hash, err := v.dataHash(m)
+	if err != nil {
+		v.log.Warn("No data hash for message, votes for it will be reported as unchecked", "src", m.Source, "nonce", m.DepositNonce, "err", err)
+	}
+	v.record(voteKey{source: m.Source, nonce: m.DepositNonce}, hash, err)
+	return v.next.ResolveMessage(m)
+}
+
+func (v *voteMonitor) record(key voteKey, hash [32]byte, err error) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	exp, ok := v.expected[key]
+	if !ok {
+		exp = &expectedHash{hash: hash, err: err, checked: make(map[common.Address]bool)}
+		v.expected[key] = exp
+	}
+	exp.block = v.latestBlock
+	for _, u := range v.unverified[key] {
+		v.check(exp, u.vote)
+	}
+	delete(v.unverified, key)
+}
+
+// observe checks the votes cast in the block. Votes of proposals with no known data hash are checked once the
+// deposit is routed to this chain.
+func (v *voteMonitor) observe(block uint64, votes []voteEvent) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if block > v.latestBlock {
+		v.latestBlock = block
+	}
+	for _, vote := range votes {
+		if vote.voter == v.relayer {
+			continue
+		}
+		key := voteKey{source: vote.source, nonce: vote.nonce}
+		if exp, ok := v.expected[key]; ok {
+			v.check(exp, vote)
+		} else if !v.isUnverified(key, vote.voter) {
+			v.unverified[key] = append(v.unverified[key], unverifiedVote{vote: vote, block: block})
+		}
+	}
+	v.prune()
+}
+
+func (v *voteMonitor) isUnverified(key voteKey, voter common.Address) bool {
+	for _, u := range v.unverified[key] {
+		if u.vote.voter == voter {
+			return true
+		}
+	}
+	return false
+}
+
+// check compares the vote with the expected data hash, raising an alert if they differ. Each relayer's vote is
+// checked once.
+func (v *voteMonitor) check(exp *expectedHash, vote voteEvent) {
+	if exp.checked[vote.voter] {
+		return
+	}
+	exp.checked[vote.voter] = true
+
+	if exp.err != nil {
+		v.log.Warn("Relayer voted for a deposit whose proposal data could not be constructed", "src", vote.source,
+			"nonce", vote.nonce, "relayer", vote.voter, "dataHash", hexutil.Encode(vote.dataHash[:]), "err", exp.err)
+		v.incUnchecked()
+		return
+	}
+	if vote.dataHash == exp.hash {
+		v.log.Debug("Vote matches deposit", "src", vote.source, "nonce", vote.nonce, "relayer", vote.voter)
+		return
+	}
+	v.log.Crit("Relayer voted for data that differs from the deposit", "src", vote.source, "nonce", vote.nonce,
+		"relayer", vote.voter, "dataHash", hexutil.Encode(vote.dataHash[:]), "expected", hexutil.Encode(exp.hash[:]))
+	if v.mismatches != nil {
+		v.mismatches.Inc()
+	}
+}
+
+// prune drops the votes and data hashes older than VoteWatchLimit blocks
+func (v *voteMonitor) prune() {
+	for key, votes := range v.unverified {
+		var kept []unverifiedVote
+		for _, u := range votes {
+			if v.latestBlock-u.block < VoteWatchLimit {
+				kept = append(kept, u)
+				continue
+			}
+			v.log.Warn("Vote could not be checked, no deposit was routed", "src", key.source, "nonce", key.nonce,
+				"relayer", u.vote.voter, "dataHash", hexutil.Encode(u.vote.dataHash[:]))
+			v.incUnchecked()
+		}
+		if len(kept) == 0 {
+			delete(v.unverified, key)
+		} else {
+			v.unverified[key] = kept
+		}
+	}
+	for key, exp := range v.expected {
+		if exp.block == 0 {
+			// Recorded before the first block was checked
+			exp.block = v.latestBlock
+		} else if v.latestBlock-exp.block >= VoteWatchLimit {
+			delete(v.expected, key)
+		}
+	}
+}
+
+func (v *voteMonitor) incUnchecked() {
+	if v.unchecked != nil {
+		v.unchecked.Inc()
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package ethereum
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ChainSafe/log15"
+	"github.com/centrifuge/chainbridge-utils/msg"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type resolveCounter int
+
+func (c *resolveCounter) ResolveMessage(m msg.Message) bool {
+	*c++
+	return true
+}
+
+func TestVoteMonitor(t *testing.T) {
+	w := &writer{cfg: Config{erc20HandlerContract: common.HexToAddress("0x01")}}
+	transfer := func(nonce msg.Nonce) msg.Message {
+		return msg.NewFungibleTransfer(1, 2, nonce, big.NewInt(100), msg.ResourceId{31: 1}, common.HexToAddress("0x02").Bytes())
+	}
+	dataHash := func(m msg.Message) [32]byte {
+		_, hash, err := w.proposalData(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return hash
+	}
+
+	self, alice, bob := common.HexToAddress("0x10"), common.HexToAddress("0x11"), common.HexToAddress("0x12")
+	vote := func(nonce msg.Nonce, voter common.Address, hash [32]byte) voteEvent {
+		return voteEvent{source: 1, nonce: nonce, dataHash: hash, voter: voter}
+	}
+	fabricated := [32]byte{1}
+
+	next := new(resolveCounter)
+	v := newVoteMonitor(next, w.proposalData, self, log15.Root())
+	v.mismatches = prometheus.NewCounter(prometheus.CounterOpts{Name: "test_vote_mismatches"})
+	v.unchecked = prometheus.NewCounter(prometheus.CounterOpts{Name: "test_unchecked_votes"})
+
+	// Votes are checked once the deposit is routed
+	v.observe(1, []voteEvent{vote(1, alice, dataHash(transfer(1))), vote(1, bob, fabricated)})
+	if mismatches := testutil.ToFloat64(v.mismatches); mismatches != 0 {
+		t.Fatalf("Got: %v Expected no mismatches before the deposit is known", mismatches)
+	}
+	v.ResolveMessage(transfer(1))
+	if mismatches := testutil.ToFloat64(v.mismatches); mismatches != 1 {
+		t.Fatalf("Got: %v Expected the vote of bob to mismatch", mismatches)
+	}
+	if *next != 1 {
+		t.Fatalf("Got: %d Expected the message to be passed on", *next)
+	}
+
+	// Votes seen again when a block is retried, and votes of this relayer are not counted
+	v.ResolveMessage(transfer(2))
+	v.observe(2, []voteEvent{vote(1, bob, fabricated), vote(2, self, fabricated), vote(2, alice, dataHash(transfer(2)))})
+	v.observe(2, []voteEvent{vote(1, bob, fabricated), vote(2, bob, fabricated)})
+	if mismatches := testutil.ToFloat64(v.mismatches); mismatches != 2 {
+		t.Fatalf("Got: %v Expected each divergent vote to be counted once", mismatches)
+	}
+
+	// Votes for messages without a data hash cannot be checked
+	invalid := transfer(4)
+	invalid.Payload = nil
+	v.ResolveMessage(invalid)
+	v.observe(3, []voteEvent{vote(4, bob, fabricated)})
+	if unchecked := testutil.ToFloat64(v.unchecked); unchecked != 1 {
+		t.Fatalf("Got: %v Expected the vote for the invalid message to be unchecked", unchecked)
+	}
+
+	// Votes waiting too long for their deposit are dropped
+	v.observe(3, []voteEvent{vote(3, bob, fabricated)})
+	v.observe(3+VoteWatchLimit, nil)
+	v.ResolveMessage(transfer(3))
+	if mismatches := testutil.ToFloat64(v.mismatches); mismatches != 2 {
+		t.Fatalf("Got: %v Expected the vote of bob to be dropped", mismatches)
+	}
+	if unchecked := testutil.ToFloat64(v.unchecked); unchecked != 2 {
+		t.Fatalf("Got: %v Expected the dropped vote to be unchecked", unchecked)
+	}
+	if len(v.expected) != 1 || len(v.unverified) != 0 {
+		t.Fatalf("Got: %d, %d Expected older data hashes and votes to be pruned", len(v.expected), len(v.unverified))
+	}
+}
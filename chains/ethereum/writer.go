@@ -6,7 +6,8 @@ package ethereum
 import (
 	"context"
 	"fmt"
-	"github.com/ChainSafe/ChainBridge/bindings/Bridge"
+
+	"github.com/ChainSafe/ChainBridge/bindings/GenericHandler"
 	"github.com/ChainSafe/log15"
 	"github.com/centrifuge/chainbridge-utils/core"
 	metrics "github.com/centrifuge/chainbridge-utils/metrics/types"
@@ -21,13 +22,14 @@ var TransferredStatus uint8 = 3
 var CancelledStatus uint8 = 4
 
 type writer struct {
-	cfg            Config
-	conn           Connection
-	bridgeContract *Bridge.Bridge // instance of bound receiver bridgeContract
-	log            log15.Logger
-	stop           <-chan int
-	sysErr         chan<- error // Reports fatal error to core
-	metrics        *metrics.ChainMetrics
+	cfg                    Config
+	conn                   Connection
+	bridge                 bridgeAdapter // instance of bound receiver bridge contract
+	genericHandlerContract *GenericHandler.GenericHandler
+	log                    log15.Logger
+	stop                   <-chan int
+	sysErr                 chan<- error // Reports fatal error to core
+	metrics                *metrics.ChainMetrics
 }
 
 // NewWriter creates and returns writer
@@ -47,9 +49,10 @@ func (w *writer) start() error {
 	return nil
 }
 
-// setContract adds the bound receiver bridgeContract to the writer
-func (w *writer) setContract(bridge *Bridge.Bridge) {
-	w.bridgeContract = bridge
+// setContract adds the bound receiver bridge contract and generic handler to the writer
+func (w *writer) setContract(bridge bridgeAdapter, genericHandler *GenericHandler.GenericHandler) {
+	w.bridge = bridge
+	w.genericHandlerContract = genericHandler
 }
 
 // ResolveMessage handles any given message based on type
@@ -67,15 +70,5 @@ func (w *writer) ResolveMessage(m msg.Message) bool {
 		panic(fmt.Errorf("chainId (%d) doesnt match with config defined mainChainId (%d)", mainChainId, w.cfg.mainChainId))
 	}
 
-	switch m.Type {
-	case msg.FungibleTransfer:
-		return w.createErc20Proposal(m)
-	case msg.NonFungibleTransfer:
-		return w.createErc721Proposal(m)
-	case msg.GenericTransfer:
-		return w.createGenericDepositProposal(m)
-	default:
-		w.log.Error("Unknown message type received", "type", m.Type)
-		return false
-	}
+	return w.createProposal(m)
 }
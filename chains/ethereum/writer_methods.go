@@ -4,14 +4,18 @@
 package ethereum
 
 import (
-	"context"
 	"errors"
+	"fmt"
 	"math/big"
 	"time"
 
+	"github.com/ChainSafe/ChainBridge/chains/generic"
+	"github.com/ChainSafe/ChainBridge/chains/payload"
+	"github.com/ChainSafe/ChainBridge/chains/recipient"
 	utils "github.com/ChainSafe/ChainBridge/shared/ethereum"
 	log "github.com/ChainSafe/log15"
 	"github.com/centrifuge/chainbridge-utils/msg"
+	"github.com/ethereum/go-ethereum/common"
 )
 
 // Number of blocks to wait for an finalization event
@@ -30,39 +34,39 @@ var ErrFatalQuery = errors.New("query of chain state failed")
 
 // proposalIsComplete returns true if the proposal state is either Passed, Transferred or Cancelled
 func (w *writer) proposalIsComplete(srcId msg.ChainId, nonce msg.Nonce, dataHash [32]byte) bool {
-	prop, err := w.bridgeContract.GetProposal(w.conn.CallOpts(), uint8(srcId), uint64(nonce), dataHash)
+	status, err := w.bridge.proposalStatus(w.conn.CallOpts(), srcId, nonce, dataHash)
 	if err != nil {
 		w.log.Error("Failed to check proposal existence", "err", err)
 		return false
 	}
-	return prop.Status == PassedStatus || prop.Status == TransferredStatus || prop.Status == CancelledStatus
+	return status == PassedStatus || status == TransferredStatus || status == CancelledStatus
 }
 
 // proposalIsComplete returns true if the proposal state is Transferred or Cancelled
 func (w *writer) proposalIsFinalized(srcId msg.ChainId, nonce msg.Nonce, dataHash [32]byte) bool {
-	prop, err := w.bridgeContract.GetProposal(w.conn.CallOpts(), uint8(srcId), uint64(nonce), dataHash)
+	status, err := w.bridge.proposalStatus(w.conn.CallOpts(), srcId, nonce, dataHash)
 	if err != nil {
 		w.log.Error("Failed to check proposal existence", "err", err)
 		return false
 	}
-	return prop.Status == TransferredStatus || prop.Status == CancelledStatus // Transferred (3)
+	return status == TransferredStatus || status == CancelledStatus // Transferred (3)
 }
 
 func (w *writer) proposalIsPassed(srcId msg.ChainId, nonce msg.Nonce, dataHash [32]byte) bool {
-	prop, err := w.bridgeContract.GetProposal(w.conn.CallOpts(), uint8(srcId), uint64(nonce), dataHash)
+	status, err := w.bridge.proposalStatus(w.conn.CallOpts(), srcId, nonce, dataHash)
 	if err != nil {
 		w.log.Error("Failed to check proposal existence", "err", err)
 		return false
 	}
 
-	w.log.Info("Proposal status", "nonce", nonce, "status", prop.Status)
+	w.log.Info("Proposal status", "nonce", nonce, "status", status)
 
-	return prop.Status == PassedStatus
+	return status == PassedStatus
 }
 
 // hasVoted checks if this relayer has already voted
 func (w *writer) hasVoted(srcId msg.ChainId, nonce msg.Nonce, dataHash [32]byte) bool {
-	hasVoted, err := w.bridgeContract.HasVotedOnProposal(w.conn.CallOpts(), utils.IDAndNonce(srcId, nonce), dataHash, w.conn.Opts().From)
+	hasVoted, err := w.bridge.hasVoted(w.conn.CallOpts(), srcId, nonce, dataHash, w.conn.Opts().From)
 	if err != nil {
 		w.log.Error("Failed to check proposal existence", "err", err)
 		return false
@@ -87,81 +91,154 @@ func (w *writer) shouldVote(m msg.Message, dataHash [32]byte) bool {
 	return true
 }
 
-// createErc20Proposal creates an Erc20 proposal.
-// Returns true if the proposal is successfully created or is complete
-func (w *writer) createErc20Proposal(m msg.Message) bool {
-	w.log.Info("Creating erc20 proposal", "src", m.Source, "nonce", m.DepositNonce)
+// proposalData constructs the proposal data of the message and its data hash, the hash relayers vote for. An error is
+// returned if no vote must be cast for the message.
+func (w *writer) proposalData(m msg.Message) ([]byte, [32]byte, error) {
+	switch m.Type {
+	case msg.FungibleTransfer:
+		return w.erc20ProposalData(m)
+	case msg.NonFungibleTransfer:
+		return w.erc721ProposalData(m)
+	case payload.SemiFungibleTransfer:
+		return w.erc1155ProposalData(m)
+	case msg.GenericTransfer:
+		return w.genericProposalData(m)
+	default:
+		return nil, [32]byte{}, fmt.Errorf("unknown message type: %s", m.Type)
+	}
+}
 
-	data := ConstructErc20ProposalData(m.Payload[0].([]byte), m.Payload[1].([]byte))
-	dataHash := utils.Hash(append(w.cfg.erc20HandlerContract.Bytes(), data...))
+func (w *writer) erc20ProposalData(m msg.Message) ([]byte, [32]byte, error) {
+	p, err := payload.ParseFungible(m)
+	if err != nil {
+		return nil, [32]byte{}, err
+	}
 
-	if !w.shouldVote(m, dataHash) {
-		if w.proposalIsPassed(m.Source, m.DepositNonce, dataHash) {
-			// We should not vote for this proposal but it is ready to be executed
-			w.executeProposal(m, data, dataHash)
-			return true
-		} else {
-			return false
-		}
+	amount, err := w.cfg.decimals.Convert(m.Source, m.ResourceId, p.Amount, payload.MaxIntBits)
+	if err != nil {
+		return nil, [32]byte{}, fmt.Errorf("invalid transfer amount: %w", err)
 	}
 
-	// Capture latest block so when know where to watch from
-	latestBlock, err := w.conn.LatestBlock()
+	recipient, err := recipient.Ethereum{}.Decode(p.Recipient)
 	if err != nil {
-		w.log.Error("Unable to fetch latest block", "err", err)
-		return false
+		return nil, [32]byte{}, fmt.Errorf("invalid recipient: %w", err)
 	}
 
-	// watch for execution event
-	go w.watchThenExecute(m, data, dataHash, latestBlock)
+	data := ConstructErc20ProposalData(amount.Bytes(), recipient)
+	return data, utils.Hash(append(w.cfg.erc20HandlerContract.Bytes(), data...)), nil
+}
 
-	w.voteProposal(m, dataHash)
+func (w *writer) erc721ProposalData(m msg.Message) ([]byte, [32]byte, error) {
+	p, err := payload.ParseNonFungible(m)
+	if err != nil {
+		return nil, [32]byte{}, err
+	}
 
-	return true
+	recipient, err := recipient.Ethereum{}.Decode(p.Recipient)
+	if err != nil {
+		return nil, [32]byte{}, fmt.Errorf("invalid recipient: %w", err)
+	}
+
+	data := ConstructErc721ProposalData(p.TokenId.Bytes(), recipient, p.Metadata)
+	return data, utils.Hash(append(w.cfg.erc721HandlerContract.Bytes(), data...)), nil
 }
 
-// createErc721Proposal creates an Erc721 proposal.
-// Returns true if the proposal is succesfully created or is complete
-func (w *writer) createErc721Proposal(m msg.Message) bool {
-	w.log.Info("Creating erc721 proposal", "src", m.Source, "nonce", m.DepositNonce)
+func (w *writer) erc1155ProposalData(m msg.Message) ([]byte, [32]byte, error) {
+	p, err := payload.ParseSemiFungible(m)
+	if err != nil {
+		return nil, [32]byte{}, err
+	}
 
-	data := ConstructErc721ProposalData(m.Payload[0].([]byte), m.Payload[1].([]byte), m.Payload[2].([]byte))
-	dataHash := utils.Hash(append(w.cfg.erc721HandlerContract.Bytes(), data...))
+	recipient, err := recipient.Ethereum{}.Decode(p.Recipient)
+	if err != nil {
+		return nil, [32]byte{}, fmt.Errorf("invalid recipient: %w", err)
+	}
 
-	if !w.shouldVote(m, dataHash) {
-		if w.proposalIsPassed(m.Source, m.DepositNonce, dataHash) {
-			// We should not vote for this proposal but it is ready to be executed
-			w.executeProposal(m, data, dataHash)
-			return true
-		} else {
-			return false
-		}
+	data, err := ConstructErc1155ProposalData(p.TokenIds, p.Amounts, recipient, p.Data)
+	if err != nil {
+		return nil, [32]byte{}, fmt.Errorf("failed to construct erc1155 proposal data: %w", err)
 	}
+	return data, utils.Hash(append(w.cfg.erc1155HandlerContract.Bytes(), data...)), nil
+}
 
-	// Capture latest block so we know where to watch from
-	latestBlock, err := w.conn.LatestBlock()
+func (w *writer) genericProposalData(m msg.Message) ([]byte, [32]byte, error) {
+	p, err := payload.ParseGeneric(m)
 	if err != nil {
-		w.log.Error("Unable to fetch latest block", "err", err)
-		return false
+		return nil, [32]byte{}, err
 	}
 
-	// watch for execution event
-	go w.watchThenExecute(m, data, dataHash, latestBlock)
+	data := ConstructGenericProposalData(p.Metadata)
+	return data, utils.Hash(append(w.cfg.genericHandlerContract.Bytes(), data...)), nil
+}
 
-	w.voteProposal(m, dataHash)
+// genericCallTarget queries the contract of the resource ID and the function the generic handler executes on it
+func (w *writer) genericCallTarget(rId msg.ResourceId) (common.Address, [4]byte, error) {
+	contract, err := w.genericHandlerContract.ResourceIDToContractAddress(w.conn.CallOpts(), rId)
+	if err != nil {
+		return common.Address{}, [4]byte{}, err
+	}
+	selector, err := w.genericHandlerContract.ContractAddressToExecuteFunctionSignature(w.conn.CallOpts(), contract)
+	if err != nil {
+		return common.Address{}, [4]byte{}, fmt.Errorf("contract %s: %w", contract.Hex(), err)
+	}
+	return contract, selector, nil
+}
 
+// checkGenericCall decodes the metadata of a generic transfer as the arguments of the function the generic handler
+// executes, and validates it against the rule of the resource ID. Returns false if no vote must be cast.
+//
+// If the rule is enforced, failed queries of the generic handler are retried until they succeed or the relayer
+// shuts down. Only call data violating the rule is refused, a transfer is never dropped because of an RPC error.
+func (w *writer) checkGenericCall(m msg.Message, rule *generic.Rule, metadata []byte) bool {
+	contract, selector, err := w.genericCallTarget(m.ResourceId)
+	for err != nil {
+		w.log.Error("Failed to query generic handler", "rId", m.ResourceId.Hex(), "err", err)
+		if !rule.Enforce {
+			return true
+		}
+		select {
+		case <-w.stop:
+			return false
+		case <-time.After(TxRetryInterval):
+		}
+		contract, selector, err = w.genericCallTarget(m.ResourceId)
+	}
+
+	call, err := rule.Validate(selector, metadata)
+	if call != nil {
+		w.log.Info("Decoded generic call", "src", m.Source, "nonce", m.DepositNonce, "contract", contract, "call", call.String())
+	}
+	if err == nil {
+		return true
+	} else if rule.Enforce {
+		w.log.Error("Generic call violates rule, not voting", "src", m.Source, "nonce", m.DepositNonce, "err", err)
+		return false
+	}
+	w.log.Warn("Generic call violates rule", "src", m.Source, "nonce", m.DepositNonce, "err", err)
 	return true
 }
 
-// createGenericDepositProposal creates a generic proposal
-// returns true if the proposal is complete or is succesfully created
-func (w *writer) createGenericDepositProposal(m msg.Message) bool {
-	w.log.Info("Creating generic proposal", "src", m.Source, "nonce", m.DepositNonce)
+// createProposal creates the proposal for the message, whatever its type.
+// Returns true if the proposal is successfully created or is complete
+func (w *writer) createProposal(m msg.Message) bool {
+	w.log.Info("Creating proposal", "type", m.Type, "src", m.Source, "nonce", m.DepositNonce)
 
-	metadata := m.Payload[0].([]byte)
-	data := ConstructGenericProposalData(metadata)
-	toHash := append(w.cfg.genericHandlerContract.Bytes(), data...)
-	dataHash := utils.Hash(toHash)
+	if m.Type == msg.GenericTransfer {
+		p, err := payload.ParseGeneric(m)
+		if err != nil {
+			w.log.Error("Invalid message, not voting", "src", m.Source, "nonce", m.DepositNonce, "err", err)
+			return false
+		}
+		if rule, ok := w.cfg.genericCalls.Lookup(m.ResourceId); ok && !w.checkGenericCall(m, rule, p.Metadata) {
+			return false
+		}
+	}
+
+	data, dataHash, err := w.proposalData(m)
+	if err != nil {
+		w.log.Error("Invalid message, not voting", "src", m.Source, "nonce", m.DepositNonce, "err", err)
+		return false
+	}
 
 	if !w.shouldVote(m, dataHash) {
 		if w.proposalIsPassed(m.Source, m.DepositNonce, dataHash) {
@@ -173,7 +250,7 @@ func (w *writer) createGenericDepositProposal(m msg.Message) bool {
 		}
 	}
 
-	// Capture latest block so when know where to watch from
+	// Capture latest block so we know where to watch from
 	latestBlock, err := w.conn.LatestBlock()
 	if err != nil {
 		w.log.Error("Unable to fetch latest block", "err", err)
@@ -183,7 +260,7 @@ func (w *writer) createGenericDepositProposal(m msg.Message) bool {
 	// watch for execution event
 	go w.watchThenExecute(m, data, dataHash, latestBlock)
 
-	w.voteProposal(m, dataHash)
+	w.voteProposal(m, data, dataHash)
 
 	return true
 }
@@ -214,9 +291,8 @@ func (w *writer) watchThenExecute(m msg.Message, data []byte, dataHash [32]byte,
 				}
 			}
 
-			// query for logs
-			query := buildQuery(w.cfg.bridgeContract, utils.ProposalEvent, latestBlock, latestBlock)
-			evts, err := w.conn.Client().FilterLogs(context.Background(), query)
+			// query for proposal events
+			evts, err := w.bridge.proposalEvents(latestBlock)
 			if err != nil {
 				w.log.Error("Failed to fetch logs", "err", err)
 				return
@@ -224,17 +300,13 @@ func (w *writer) watchThenExecute(m msg.Message, data []byte, dataHash [32]byte,
 
 			// execute the proposal once we find the matching finalized event
 			for _, evt := range evts {
-				sourceId := evt.Topics[1].Big().Uint64()
-				depositNonce := evt.Topics[2].Big().Uint64()
-				status := evt.Topics[3].Big().Uint64()
-
-				if m.Source == msg.ChainId(sourceId) &&
-					m.DepositNonce.Big().Uint64() == depositNonce &&
-					utils.IsFinalized(uint8(status)) {
+				if m.Source == evt.source &&
+					m.DepositNonce == evt.nonce &&
+					utils.IsFinalized(evt.status) {
 					w.executeProposal(m, data, dataHash)
 					return
 				} else {
-					w.log.Trace("Ignoring event", "src", sourceId, "nonce", depositNonce)
+					w.log.Trace("Ignoring event", "src", evt.source, "nonce", evt.nonce)
 				}
 			}
 			w.log.Trace("No finalization event found in current block", "block", latestBlock, "src", m.Source, "nonce", m.DepositNonce)
@@ -246,7 +318,7 @@ func (w *writer) watchThenExecute(m msg.Message, data []byte, dataHash [32]byte,
 
 // voteProposal submits a vote proposal
 // a vote proposal will try to be submitted up to the TxRetryLimit times
-func (w *writer) voteProposal(m msg.Message, dataHash [32]byte) {
+func (w *writer) voteProposal(m msg.Message, data []byte, dataHash [32]byte) {
 	for i := 0; i < TxRetryLimit; i++ {
 		select {
 		case <-w.stop:
@@ -258,13 +330,7 @@ func (w *writer) voteProposal(m msg.Message, dataHash [32]byte) {
 				continue
 			}
 
-			tx, err := w.bridgeContract.VoteProposal(
-				w.conn.Opts(),
-				uint8(m.Source),
-				uint64(m.DepositNonce),
-				m.ResourceId,
-				dataHash,
-			)
+			tx, err := w.bridge.voteProposal(w.conn.Opts(), m, data, dataHash)
 			w.conn.UnlockOpts()
 
 			if err == nil {
@@ -305,13 +371,7 @@ func (w *writer) executeProposal(m msg.Message, data []byte, dataHash [32]byte)
 				return
 			}
 
-			tx, err := w.bridgeContract.ExecuteProposal(
-				w.conn.Opts(),
-				uint8(m.Source),
-				uint64(m.DepositNonce),
-				data,
-				m.ResourceId,
-			)
+			tx, err := w.bridge.executeProposal(w.conn.Opts(), m, data)
 			w.conn.UnlockOpts()
 
 			if err == nil {
@@ -336,3 +396,37 @@ func (w *writer) executeProposal(m msg.Message, data []byte, dataHash [32]byte)
 	w.log.Error("Submission of Execute transaction failed", "source", m.Source, "dest", m.Destination, "depositNonce", m.DepositNonce)
 	w.sysErr <- ErrFatalTx
 }
+
+// pauseTransfers pauses the bridge, as the volume of the route from the source chain exceeded its limit
+func (w *writer) pauseTransfers(source msg.ChainId, reason string) {
+	for i := 0; i < TxRetryLimit; i++ {
+		select {
+		case <-w.stop:
+			return
+		default:
+			err := w.conn.LockAndUpdateOpts()
+			if err != nil {
+				w.log.Error("Failed to update tx opts", "err", err)
+				continue
+			}
+
+			tx, err := w.bridge.pauseTransfers(w.conn.Opts())
+			w.conn.UnlockOpts()
+
+			if err == nil {
+				w.log.Warn("Submitted bridge pause", "tx", tx.Hash(), "src", source, "reason", reason)
+				return
+			} else if errors.Is(err, ErrNotAdmin) {
+				w.log.Error("Unable to pause bridge, the relayer is not an admin", "src", source)
+				return
+			} else if err.Error() == ErrNonceTooLow.Error() || err.Error() == ErrTxUnderpriced.Error() {
+				w.log.Debug("Nonce too low, will retry")
+				time.Sleep(TxRetryInterval)
+			} else {
+				w.log.Warn("Pausing bridge failed", "src", source, "err", err)
+				time.Sleep(TxRetryInterval)
+			}
+		}
+	}
+	w.log.Error("Submission of pause transaction failed", "src", source)
+}
@@ -9,7 +9,7 @@ import (
 	"testing"
 	"time"
 
-	"github.com/ChainSafe/ChainBridge/bindings/Bridge"
+	"github.com/ChainSafe/ChainBridge/bindings/GenericHandler"
 	utils "github.com/ChainSafe/ChainBridge/shared/ethereum"
 	ethtest "github.com/ChainSafe/ChainBridge/shared/ethereum/testing"
 	"github.com/ChainSafe/log15"
@@ -35,12 +35,17 @@ func createTestWriter(t *testing.T, cfg *Config, errs chan<- error) (*writer, fu
 	stop := make(chan int)
 	writer := NewWriter(conn, cfg, newTestLogger(cfg.name), stop, errs, nil)
 
-	bridge, err := Bridge.NewBridge(cfg.bridgeContract, conn.Client())
+	bridge, err := newLegacyBridge(cfg.bridgeContract, conn.Client())
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	writer.setContract(bridge)
+	genericHandler, err := GenericHandler.NewGenericHandler(cfg.genericHandlerContract, conn.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	writer.setContract(bridge, genericHandler)
 
 	err = writer.start()
 	if err != nil {
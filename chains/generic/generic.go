@@ -0,0 +1,298 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+/*
+The generic package decodes and validates the metadata of generic transfers. The metadata of a generic transfer is
+passed to the contract of its resource ID as the arguments of the function the generic handler is configured to
+execute (see ContractAddressToExecuteFunctionSignature), so it can be decoded with an ABI fragment of that contract.
+
+Rules are configured per resource ID in a JSON file of the form
+
+	{
+		"<resourceId>": {
+			"abi": [<ABI fragment>],
+			"selectors": ["0xa9059cbb"],
+			"bounds": {"<argument>": {"min": "1", "max": "1000"}},
+			"enforce": true
+		}
+	}
+
+The selectors are the functions that may be executed, any function may be executed if none are configured. Bounds
+apply to the arguments with the given name. Integers are bounded by their value, bytes, strings and arrays by their
+length. Violations only block a transfer if the rule is enforced, otherwise they are only reported.
+*/
+package generic
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/centrifuge/chainbridge-utils/msg"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var ErrSelectorNotAllowed = errors.New("function selector not allowed")
+var ErrUndecodable = errors.New("metadata does not match the function arguments")
+var ErrOutOfBounds = errors.New("argument out of bounds")
+
+// Bound limits an argument, both limits are inclusive and optional
+type Bound struct {
+	Min *big.Int
+	Max *big.Int
+}
+
+// Rule decodes and validates the metadata of a resource
+type Rule struct {
+	ABI       abi.ABI
+	Selectors [][4]byte
+	Bounds    map[string]Bound
+	Enforce   bool
+}
+
+// Registry holds the rules of the generic resources transferred to a chain
+type Registry struct {
+	rules map[msg.ResourceId]*Rule
+}
+
+type boundConfig struct {
+	Min string `json:"min"`
+	Max string `json:"max"`
+}
+
+type ruleConfig struct {
+	ABI       json.RawMessage        `json:"abi"`
+	Selectors []string               `json:"selectors"`
+	Bounds    map[string]boundConfig `json:"bounds"`
+	Enforce   bool                   `json:"enforce"`
+}
+
+// Load reads the rules from a file (see package documentation)
+func Load(path string) (*Registry, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Parse constructs a registry from its configured form (see package documentation)
+func Parse(data []byte) (*Registry, error) {
+	var cfg map[string]ruleConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, err
+	}
+
+	r := &Registry{rules: make(map[msg.ResourceId]*Rule)}
+	for id, rc := range cfg {
+		rIdBytes, err := hex.DecodeString(strings.TrimPrefix(id, "0x"))
+		if err != nil || len(rIdBytes) != 32 {
+			return nil, fmt.Errorf("invalid resource ID %s", id)
+		}
+		rule, err := parseRule(rc)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rule of resource %s: %w", id, err)
+		}
+		r.rules[msg.ResourceIdFromSlice(rIdBytes)] = rule
+	}
+	return r, nil
+}
+
+func parseRule(rc ruleConfig) (*Rule, error) {
+	rule := &Rule{Bounds: make(map[string]Bound), Enforce: rc.Enforce}
+	if len(rc.ABI) != 0 {
+		parsed, err := abi.JSON(bytes.NewReader(rc.ABI))
+		if err != nil {
+			return nil, fmt.Errorf("invalid ABI: %w", err)
+		}
+		rule.ABI = parsed
+	}
+
+	for _, s := range rc.Selectors {
+		sel, err := hexutil.Decode(s)
+		if err != nil || len(sel) != 4 {
+			return nil, fmt.Errorf("invalid selector %s", s)
+		}
+		var selector [4]byte
+		copy(selector[:], sel)
+		rule.Selectors = append(rule.Selectors, selector)
+	}
+
+	for name, bc := range rc.Bounds {
+		if err := rule.checkBoundedArgument(name); err != nil {
+			return nil, err
+		}
+		var b Bound
+		var err error
+		if b.Min, err = parseLimit(bc.Min); err != nil {
+			return nil, err
+		}
+		if b.Max, err = parseLimit(bc.Max); err != nil {
+			return nil, err
+		}
+		if b.Min != nil && b.Max != nil && b.Min.Cmp(b.Max) > 0 {
+			return nil, fmt.Errorf("min of argument %s exceeds its max", name)
+		}
+		rule.Bounds[name] = b
+	}
+	return rule, nil
+}
+
+func parseLimit(s string) (*big.Int, error) {
+	if s == "" {
+		return nil, nil
+	}
+	v, ok := new(big.Int).SetString(s, 0)
+	if !ok {
+		return nil, fmt.Errorf("invalid limit %s", s)
+	}
+	return v, nil
+}
+
+// checkBoundedArgument ensures a function of the ABI takes a boundable argument of the name
+func (r *Rule) checkBoundedArgument(name string) error {
+	found := false
+	for _, method := range r.ABI.Methods {
+		for _, input := range method.Inputs {
+			if input.Name != name {
+				continue
+			}
+			switch input.Type.T {
+			case abi.IntTy, abi.UintTy, abi.BytesTy, abi.StringTy, abi.SliceTy, abi.ArrayTy:
+				found = true
+			default:
+				return fmt.Errorf("argument %s of type %s cannot be bounded", name, input.Type)
+			}
+		}
+	}
+	if !found {
+		return fmt.Errorf("no function takes argument %s", name)
+	}
+	return nil
+}
+
+// Lookup returns the rule of the resource
+func (r *Registry) Lookup(rId msg.ResourceId) (*Rule, bool) {
+	if r == nil {
+		return nil, false
+	}
+	rule, ok := r.rules[rId]
+	return rule, ok
+}
+
+// Call is metadata decoded as the arguments of a function
+type Call struct {
+	Method *abi.Method
+	Args   []interface{}
+}
+
+// String formats the call as the function name and its named arguments
+func (c *Call) String() string {
+	args := make([]string, len(c.Args))
+	for i, arg := range c.Args {
+		args[i] = fmt.Sprintf("%s=%s", c.Method.Inputs[i].Name, formatArg(arg))
+	}
+	return fmt.Sprintf("%s(%s)", c.Method.RawName, strings.Join(args, ", "))
+}
+
+func formatArg(arg interface{}) string {
+	switch a := arg.(type) {
+	case fmt.Stringer:
+		return a.String()
+	case []byte:
+		return hexutil.Encode(a)
+	}
+	v := reflect.ValueOf(arg)
+	if v.Kind() == reflect.Array && v.Type().Elem().Kind() == reflect.Uint8 {
+		b := make([]byte, v.Len())
+		reflect.Copy(reflect.ValueOf(b), v)
+		return hexutil.Encode(b)
+	}
+	return fmt.Sprintf("%v", arg)
+}
+
+// Decode decodes the metadata as the arguments of the function with the selector. The metadata must be the exact
+// encoding of the arguments.
+func (r *Rule) Decode(selector [4]byte, metadata []byte) (*Call, error) {
+	method, err := r.ABI.MethodById(selector[:])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUndecodable, err)
+	}
+	args, err := method.Inputs.Unpack(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUndecodable, err)
+	}
+	// Unpacking ignores trailing data, which would still be passed to the function
+	packed, err := method.Inputs.Pack(args...)
+	if err != nil || !bytes.Equal(packed, metadata) {
+		return nil, fmt.Errorf("%w: metadata is not the canonical encoding of %s", ErrUndecodable, method.Sig)
+	}
+	return &Call{Method: method, Args: args}, nil
+}
+
+// Validate checks the selector is allowed, and the metadata decodes into arguments within their bounds. The decoded
+// call is returned if the metadata could be decoded, even if it is out of bounds.
+func (r *Rule) Validate(selector [4]byte, metadata []byte) (*Call, error) {
+	if !r.allows(selector) {
+		return nil, fmt.Errorf("%w: %s", ErrSelectorNotAllowed, hexutil.Encode(selector[:]))
+	}
+	if len(r.ABI.Methods) == 0 {
+		// Nothing to decode the metadata with
+		return nil, nil
+	}
+	call, err := r.Decode(selector, metadata)
+	if err != nil {
+		return nil, err
+	}
+	for i, input := range call.Method.Inputs {
+		b, ok := r.Bounds[input.Name]
+		if !ok {
+			continue
+		}
+		v := boundedValue(call.Args[i])
+		if v == nil {
+			continue
+		}
+		if (b.Min != nil && v.Cmp(b.Min) < 0) || (b.Max != nil && v.Cmp(b.Max) > 0) {
+			return call, fmt.Errorf("%w: %s is %s", ErrOutOfBounds, input.Name, v)
+		}
+	}
+	return call, nil
+}
+
+func (r *Rule) allows(selector [4]byte) bool {
+	if len(r.Selectors) == 0 {
+		return true
+	}
+	for _, s := range r.Selectors {
+		if s == selector {
+			return true
+		}
+	}
+	return false
+}
+
+// boundedValue returns the value of integers and the length of bytes, strings and arrays
+func boundedValue(arg interface{}) *big.Int {
+	if v, ok := arg.(*big.Int); ok {
+		return v
+	}
+	v := reflect.ValueOf(arg)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewInt(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return new(big.Int).SetUint64(v.Uint())
+	case reflect.Slice, reflect.Array, reflect.String:
+		return big.NewInt(int64(v.Len()))
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package generic
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/chainbridge-utils/msg"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const transferAbi = `[{"inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"},{"name":"memo","type":"bytes"}],"name":"transfer","outputs":[],"stateMutability":"nonpayable","type":"function"}]`
+
+func testRegistry(t *testing.T, rId msg.ResourceId, rule string) *Registry {
+	r, err := Parse([]byte(fmt.Sprintf(`{"0x%x": %s}`, rId, rule)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func TestParse(t *testing.T) {
+	rId := msg.ResourceId{31: 1}
+
+	r := testRegistry(t, rId, fmt.Sprintf(`{"abi": %s, "selectors": ["0x12345678"], "bounds": {"amount": {"max": "100"}}, "enforce": true}`, transferAbi))
+	rule, ok := r.Lookup(rId)
+	if !ok {
+		t.Fatal("expected rule of resource")
+	}
+	if !rule.Enforce || len(rule.Selectors) != 1 || rule.Selectors[0] != [4]byte{0x12, 0x34, 0x56, 0x78} {
+		t.Fatalf("unexpected rule: %#v", rule)
+	}
+	if _, ok := r.Lookup(msg.ResourceId{31: 2}); ok {
+		t.Fatal("unexpected rule of unknown resource")
+	}
+	var nilRegistry *Registry
+	if _, ok := nilRegistry.Lookup(rId); ok {
+		t.Fatal("unexpected rule in nil registry")
+	}
+
+	invalid := []string{
+		`{"0x01": {}}`,
+		fmt.Sprintf(`{"0x%x": {"selectors": ["0x1234"]}}`, rId),
+		fmt.Sprintf(`{"0x%x": {"abi": %s, "bounds": {"value": {"max": "1"}}}}`, rId, transferAbi),
+		fmt.Sprintf(`{"0x%x": {"abi": %s, "bounds": {"to": {"max": "1"}}}}`, rId, transferAbi),
+		fmt.Sprintf(`{"0x%x": {"abi": %s, "bounds": {"amount": {"min": "2", "max": "1"}}}}`, rId, transferAbi),
+		fmt.Sprintf(`{"0x%x": {"abi": %s, "bounds": {"amount": {"max": "one"}}}}`, rId, transferAbi),
+	}
+	for _, s := range invalid {
+		if _, err := Parse([]byte(s)); err == nil {
+			t.Errorf("expected error for %s", s)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	rId := msg.ResourceId{31: 1}
+	r := testRegistry(t, rId, fmt.Sprintf(`{"abi": %s, "bounds": {"amount": {"min": "1", "max": "100"}, "memo": {"max": "4"}}}`, transferAbi))
+	rule, _ := r.Lookup(rId)
+
+	method := rule.ABI.Methods["transfer"]
+	var selector [4]byte
+	copy(selector[:], method.ID)
+	to := common.HexToAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+
+	metadata, err := method.Inputs.Pack(to, big.NewInt(50), []byte{0xde, 0xad})
+	if err != nil {
+		t.Fatal(err)
+	}
+	call, err := rule.Validate(selector, metadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := fmt.Sprintf("transfer(to=%s, amount=50, memo=0xdead)", to.Hex())
+	if call.String() != expected {
+		t.Fatalf("Got: %s Expected: %s", call, expected)
+	}
+
+	testCases := []struct {
+		name     string
+		selector [4]byte
+		metadata func() []byte
+		err      error
+	}{
+		{
+			name:     "unknown selector",
+			selector: [4]byte{1, 2, 3, 4},
+			metadata: func() []byte { return metadata },
+			err:      ErrUndecodable,
+		},
+		{
+			name:     "trailing data",
+			selector: selector,
+			metadata: func() []byte { return append(append([]byte{}, metadata...), 0x01) },
+			err:      ErrUndecodable,
+		},
+		{
+			name:     "amount out of bounds",
+			selector: selector,
+			metadata: func() []byte {
+				data, _ := method.Inputs.Pack(to, big.NewInt(101), []byte{})
+				return data
+			},
+			err: ErrOutOfBounds,
+		},
+		{
+			name:     "memo out of bounds",
+			selector: selector,
+			metadata: func() []byte {
+				data, _ := method.Inputs.Pack(to, big.NewInt(1), []byte{1, 2, 3, 4, 5})
+				return data
+			},
+			err: ErrOutOfBounds,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := rule.Validate(tc.selector, tc.metadata()); !errors.Is(err, tc.err) {
+				t.Fatalf("Got: %v Expected: %v", err, tc.err)
+			}
+		})
+	}
+
+	// Only the configured selectors may be executed
+	r = testRegistry(t, rId, `{"selectors": ["0x12345678"]}`)
+	rule, _ = r.Lookup(rId)
+	if _, err := rule.Validate(selector, metadata); !errors.Is(err, ErrSelectorNotAllowed) {
+		t.Fatalf("Got: %v Expected: %v", err, ErrSelectorNotAllowed)
+	}
+	if call, err := rule.Validate([4]byte{0x12, 0x34, 0x56, 0x78}, metadata); err != nil || call != nil {
+		t.Fatalf("Got: %v/%v Expected no call and no error", call, err)
+	}
+}
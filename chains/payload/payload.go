@@ -0,0 +1,288 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+/*
+The payload package defines typed payloads for the fungible, non-fungible, semi-fungible and generic transfer types.
+Listeners construct messages from payloads, and writers parse payloads from messages. As msg.Message carries its
+payload as []interface{}, parsing checks the number and type of the fields, so a malformed message results in an
+error wrapping ErrInvalidPayload rather than a panic.
+*/
+package payload
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/centrifuge/chainbridge-utils/msg"
+)
+
+var ErrInvalidPayload = errors.New("invalid payload")
+
+// ErrNoAmount is returned by Amount for transfer types without an amount
+var ErrNoAmount = errors.New("transfer has no amount")
+
+// MaxIntBits bounds amounts and token IDs, which are uint256 on the bridge contracts
+const MaxIntBits = 256
+
+// Payload is implemented by the payloads of all transfer types
+type Payload interface {
+	// Validate returns an error wrapping ErrInvalidPayload if the payload cannot be transferred
+	Validate() error
+}
+
+var _ Payload = &Fungible{}
+var _ Payload = &NonFungible{}
+var _ Payload = &Generic{}
+var _ Payload = &SemiFungible{}
+
+// Fungible is the payload of msg.FungibleTransfer messages
+type Fungible struct {
+	Amount    *big.Int
+	Recipient []byte
+}
+
+func (p *Fungible) Validate() error {
+	if err := validateInt("amount", p.Amount); err != nil {
+		return err
+	}
+	return validateRecipient(p.Recipient)
+}
+
+// Message returns the message transferring the payload
+func (p *Fungible) Message(source, dest msg.ChainId, nonce msg.Nonce, rId msg.ResourceId) msg.Message {
+	return msg.NewFungibleTransfer(source, dest, nonce, p.Amount, rId, p.Recipient)
+}
+
+// ParseFungible returns the validated payload of a msg.FungibleTransfer message
+func ParseFungible(m msg.Message) (*Fungible, error) {
+	fields, err := bytesFields(m, msg.FungibleTransfer, 2)
+	if err != nil {
+		return nil, err
+	}
+	p := &Fungible{
+		Amount:    new(big.Int).SetBytes(fields[0]),
+		Recipient: fields[1],
+	}
+	return p, p.Validate()
+}
+
+// NonFungible is the payload of msg.NonFungibleTransfer messages
+type NonFungible struct {
+	TokenId   *big.Int
+	Recipient []byte
+	Metadata  []byte
+}
+
+func (p *NonFungible) Validate() error {
+	if err := validateInt("token ID", p.TokenId); err != nil {
+		return err
+	}
+	return validateRecipient(p.Recipient)
+}
+
+// Message returns the message transferring the payload
+func (p *NonFungible) Message(source, dest msg.ChainId, nonce msg.Nonce, rId msg.ResourceId) msg.Message {
+	return msg.NewNonFungibleTransfer(source, dest, nonce, rId, p.TokenId, p.Recipient, p.Metadata)
+}
+
+// ParseNonFungible returns the validated payload of a msg.NonFungibleTransfer message
+func ParseNonFungible(m msg.Message) (*NonFungible, error) {
+	fields, err := bytesFields(m, msg.NonFungibleTransfer, 3)
+	if err != nil {
+		return nil, err
+	}
+	p := &NonFungible{
+		TokenId:   new(big.Int).SetBytes(fields[0]),
+		Recipient: fields[1],
+		Metadata:  fields[2],
+	}
+	return p, p.Validate()
+}
+
+// Generic is the payload of msg.GenericTransfer messages. The metadata is interpreted by the destination chain.
+type Generic struct {
+	Metadata []byte
+}
+
+// Validate accepts any metadata, including none
+func (p *Generic) Validate() error {
+	return nil
+}
+
+// Message returns the message transferring the payload
+func (p *Generic) Message(source, dest msg.ChainId, nonce msg.Nonce, rId msg.ResourceId) msg.Message {
+	return msg.NewGenericTransfer(source, dest, nonce, rId, p.Metadata)
+}
+
+// ParseGeneric returns the validated payload of a msg.GenericTransfer message
+func ParseGeneric(m msg.Message) (*Generic, error) {
+	fields, err := bytesFields(m, msg.GenericTransfer, 1)
+	if err != nil {
+		return nil, err
+	}
+	p := &Generic{Metadata: fields[0]}
+	return p, p.Validate()
+}
+
+// SemiFungibleTransfer is the transfer type of multi-token transfers (eg. ERC1155), which move amounts of several
+// token IDs at once
+var SemiFungibleTransfer msg.TransferType = "SemiFungibleTransfer"
+
+// SemiFungible is the payload of SemiFungibleTransfer messages
+type SemiFungible struct {
+	TokenIds  []*big.Int
+	Amounts   []*big.Int // Amount of each token ID
+	Recipient []byte
+	Data      []byte // Passed on to the receiver of the tokens
+}
+
+func (p *SemiFungible) Validate() error {
+	if len(p.TokenIds) == 0 {
+		return fmt.Errorf("%w: missing token IDs", ErrInvalidPayload)
+	}
+	if len(p.TokenIds) != len(p.Amounts) {
+		return fmt.Errorf("%w: %d token IDs with %d amounts", ErrInvalidPayload, len(p.TokenIds), len(p.Amounts))
+	}
+	for i := range p.TokenIds {
+		if err := validateInt("token ID", p.TokenIds[i]); err != nil {
+			return err
+		}
+		if err := validateInt("amount", p.Amounts[i]); err != nil {
+			return err
+		}
+	}
+	return validateRecipient(p.Recipient)
+}
+
+// Message returns the message transferring the payload
+func (p *SemiFungible) Message(source, dest msg.ChainId, nonce msg.Nonce, rId msg.ResourceId) msg.Message {
+	return msg.Message{
+		Source:       source,
+		Destination:  dest,
+		Type:         SemiFungibleTransfer,
+		DepositNonce: nonce,
+		ResourceId:   rId,
+		Payload: []interface{}{
+			p.TokenIds,
+			p.Amounts,
+			p.Recipient,
+			p.Data,
+		},
+	}
+}
+
+// ParseSemiFungible returns the validated payload of a SemiFungibleTransfer message
+func ParseSemiFungible(m msg.Message) (*SemiFungible, error) {
+	if err := checkMessage(m, SemiFungibleTransfer, 4); err != nil {
+		return nil, err
+	}
+	tokenIds, err := intsField(m, 0)
+	if err != nil {
+		return nil, err
+	}
+	amounts, err := intsField(m, 1)
+	if err != nil {
+		return nil, err
+	}
+	recipient, err := bytesField(m, 2)
+	if err != nil {
+		return nil, err
+	}
+	data, err := bytesField(m, 3)
+	if err != nil {
+		return nil, err
+	}
+	p := &SemiFungible{
+		TokenIds:  tokenIds,
+		Amounts:   amounts,
+		Recipient: recipient,
+		Data:      data,
+	}
+	return p, p.Validate()
+}
+
+// Amount returns the amount of fungible transfers, and the total amount of semi-fungible transfers
+func Amount(m msg.Message) (*big.Int, error) {
+	switch m.Type {
+	case msg.FungibleTransfer:
+		p, err := ParseFungible(m)
+		if err != nil {
+			return nil, err
+		}
+		return p.Amount, nil
+	case SemiFungibleTransfer:
+		p, err := ParseSemiFungible(m)
+		if err != nil {
+			return nil, err
+		}
+		total := big.NewInt(0)
+		for _, amount := range p.Amounts {
+			total.Add(total, amount)
+		}
+		return total, nil
+	default:
+		return nil, ErrNoAmount
+	}
+}
+
+// checkMessage ensures the message is of type t and has n payload fields
+func checkMessage(m msg.Message, t msg.TransferType, n int) error {
+	if m.Type != t {
+		return fmt.Errorf("%w: expected %s message, got %s", ErrInvalidPayload, t, m.Type)
+	}
+	if len(m.Payload) != n {
+		return fmt.Errorf("%w: expected %d fields, got %d", ErrInvalidPayload, n, len(m.Payload))
+	}
+	return nil
+}
+
+// bytesFields returns the payload of the message, which must be of type t and consist of n byte slices
+func bytesFields(m msg.Message, t msg.TransferType, n int) ([][]byte, error) {
+	if err := checkMessage(m, t, n); err != nil {
+		return nil, err
+	}
+
+	fields := make([][]byte, n)
+	for i := range m.Payload {
+		bz, err := bytesField(m, i)
+		if err != nil {
+			return nil, err
+		}
+		fields[i] = bz
+	}
+	return fields, nil
+}
+
+func bytesField(m msg.Message, i int) ([]byte, error) {
+	bz, ok := m.Payload[i].([]byte)
+	if !ok {
+		return nil, fmt.Errorf("%w: field %d has type %T, expected bytes", ErrInvalidPayload, i, m.Payload[i])
+	}
+	return bz, nil
+}
+
+func intsField(m msg.Message, i int) ([]*big.Int, error) {
+	ints, ok := m.Payload[i].([]*big.Int)
+	if !ok {
+		return nil, fmt.Errorf("%w: field %d has type %T, expected []*big.Int", ErrInvalidPayload, i, m.Payload[i])
+	}
+	return ints, nil
+}
+
+func validateInt(name string, v *big.Int) error {
+	if v == nil {
+		return fmt.Errorf("%w: missing %s", ErrInvalidPayload, name)
+	}
+	if v.Sign() < 0 || v.BitLen() > MaxIntBits {
+		return fmt.Errorf("%w: %s %s is not a uint%d", ErrInvalidPayload, name, v, MaxIntBits)
+	}
+	return nil
+}
+
+func validateRecipient(recipient []byte) error {
+	if len(recipient) == 0 {
+		return fmt.Errorf("%w: missing recipient", ErrInvalidPayload)
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package payload
+
+import (
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/centrifuge/chainbridge-utils/msg"
+)
+
+func TestRoundTrip(t *testing.T) {
+	rId := msg.ResourceId{1}
+
+	fungible := &Fungible{Amount: big.NewInt(100), Recipient: []byte{0xab}}
+	p, err := ParseFungible(fungible.Message(1, 2, 3, rId))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(p, fungible) {
+		t.Fatalf("Got: %#v Expected: %#v", p, fungible)
+	}
+
+	nonFungible := &NonFungible{TokenId: big.NewInt(7), Recipient: []byte{0xab}, Metadata: []byte{}}
+	nf, err := ParseNonFungible(nonFungible.Message(1, 2, 3, rId))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(nf, nonFungible) {
+		t.Fatalf("Got: %#v Expected: %#v", nf, nonFungible)
+	}
+
+	semiFungible := &SemiFungible{
+		TokenIds:  []*big.Int{big.NewInt(1), big.NewInt(2)},
+		Amounts:   []*big.Int{big.NewInt(10), big.NewInt(20)},
+		Recipient: []byte{0xab},
+		Data:      []byte{},
+	}
+	sf, err := ParseSemiFungible(semiFungible.Message(1, 2, 3, rId))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(sf, semiFungible) {
+		t.Fatalf("Got: %#v Expected: %#v", sf, semiFungible)
+	}
+
+	generic := &Generic{Metadata: []byte{1, 2, 3}}
+	g, err := ParseGeneric(generic.Message(1, 2, 3, rId))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(g, generic) {
+		t.Fatalf("Got: %#v Expected: %#v", g, generic)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	rId := msg.ResourceId{1}
+	tooLarge := new(big.Int).Lsh(big.NewInt(1), MaxIntBits)
+
+	testCases := []struct {
+		name  string
+		m     msg.Message
+		parse func(msg.Message) error
+	}{
+		{
+			name:  "wrong type",
+			m:     msg.NewGenericTransfer(1, 2, 3, rId, []byte{}),
+			parse: func(m msg.Message) error { _, err := ParseFungible(m); return err },
+		},
+		{
+			name:  "missing field",
+			m:     msg.Message{Type: msg.FungibleTransfer, Payload: []interface{}{[]byte{1}}},
+			parse: func(m msg.Message) error { _, err := ParseFungible(m); return err },
+		},
+		{
+			name:  "field type",
+			m:     msg.Message{Type: msg.NonFungibleTransfer, Payload: []interface{}{[]byte{1}, "recipient", []byte{}}},
+			parse: func(m msg.Message) error { _, err := ParseNonFungible(m); return err },
+		},
+		{
+			name:  "empty recipient",
+			m:     msg.NewFungibleTransfer(1, 2, 3, big.NewInt(1), rId, []byte{}),
+			parse: func(m msg.Message) error { _, err := ParseFungible(m); return err },
+		},
+		{
+			name:  "token ID overflow",
+			m:     msg.NewNonFungibleTransfer(1, 2, 3, rId, tooLarge, []byte{1}, nil),
+			parse: func(m msg.Message) error { _, err := ParseNonFungible(m); return err },
+		},
+		{
+			name: "amounts mismatch",
+			m: (&SemiFungible{
+				TokenIds:  []*big.Int{big.NewInt(1), big.NewInt(2)},
+				Amounts:   []*big.Int{big.NewInt(10)},
+				Recipient: []byte{1},
+			}).Message(1, 2, 3, rId),
+			parse: func(m msg.Message) error { _, err := ParseSemiFungible(m); return err },
+		},
+		{
+			name:  "token IDs type",
+			m:     msg.Message{Type: SemiFungibleTransfer, Payload: []interface{}{[]byte{1}, []*big.Int{}, []byte{1}, []byte{}}},
+			parse: func(m msg.Message) error { _, err := ParseSemiFungible(m); return err },
+		},
+		{
+			name:  "nil payload",
+			m:     msg.Message{Type: msg.GenericTransfer},
+			parse: func(m msg.Message) error { _, err := ParseGeneric(m); return err },
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.parse(tc.m); !errors.Is(err, ErrInvalidPayload) {
+				t.Fatalf("Got: %v Expected: %v", err, ErrInvalidPayload)
+			}
+		})
+	}
+
+	if err := (&Fungible{Recipient: []byte{1}}).Validate(); !errors.Is(err, ErrInvalidPayload) {
+		t.Fatalf("Got: %v Expected: %v", err, ErrInvalidPayload)
+	}
+}
+
+func TestAmount(t *testing.T) {
+	rId := msg.ResourceId{1}
+	amount, err := Amount(msg.NewFungibleTransfer(1, 2, 3, big.NewInt(10), rId, []byte{0xab}))
+	if err != nil || amount.Cmp(big.NewInt(10)) != 0 {
+		t.Fatalf("Got: %v, %v Expected: 10", amount, err)
+	}
+
+	sf := &SemiFungible{
+		TokenIds:  []*big.Int{big.NewInt(1), big.NewInt(2)},
+		Amounts:   []*big.Int{big.NewInt(5), big.NewInt(7)},
+		Recipient: []byte{0xab},
+	}
+	amount, err = Amount(sf.Message(1, 2, 3, rId))
+	if err != nil || amount.Cmp(big.NewInt(12)) != 0 {
+		t.Fatalf("Got: %v, %v Expected: 12", amount, err)
+	}
+
+	if _, err = Amount(msg.NewGenericTransfer(1, 2, 3, rId, nil)); !errors.Is(err, ErrNoAmount) {
+		t.Fatalf("Got: %v Expected: %v", err, ErrNoAmount)
+	}
+}
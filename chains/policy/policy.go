@@ -0,0 +1,333 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+/*
+The policy package filters deposits before they are routed to their destination chain. Policies are configured per
+route on the source chain, in a JSON file of the form
+
+	{
+		"allowedDestinations": [1, 2],
+		"default": <policy>,
+		"routes": {"<destinationChainId>": <policy>}
+	}
+
+Deposits to destinations that are not allowed are rejected, any destination is allowed if none are configured. The
+policy of a route replaces the default policy for deposits to that destination. A policy takes the form
+
+	{
+		"allowedResources": ["<resourceId>"],
+		"deniedResources": ["<resourceId>"],
+		"amounts": {"<resourceId>": {"min": "1", "max": "1000"}},
+		"deniedSenders": ["<address>"],
+		"deniedRecipients": ["<address>"]
+	}
+
+Any resource is allowed if no allowed resources are configured. Amounts are the amounts of fungible and
+semi-fungible transfers as deposited, before any decimal conversion. Addresses are hex encoded, or SS58 encoded for
+substrate accounts. Senders are only known for deposits on Ethereum chains.
+*/
+package policy
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ChainSafe/ChainBridge/chains/payload"
+	"github.com/ChainSafe/ChainBridge/chains/recipient"
+	"github.com/centrifuge/chainbridge-utils/msg"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Reasons of rejected deposits
+const (
+	ReasonDestinationDenied = "destination_denied"
+	ReasonResourceDenied    = "resource_denied"
+	ReasonAmountBelowMin    = "amount_below_min"
+	ReasonAmountAboveMax    = "amount_above_max"
+	ReasonSenderDenied      = "sender_denied"
+	ReasonRecipientDenied   = "recipient_denied"
+)
+
+// Violation is the error of a deposit rejected by a policy. It implements deadletter.Rejection, so the reason is
+// recorded with the deposit.
+type Violation struct {
+	Reason string
+	Detail string
+}
+
+func (v *Violation) Error() string {
+	return fmt.Sprintf("deposit rejected by policy (%s): %s", v.Reason, v.Detail)
+}
+
+func (v *Violation) RejectReason() string {
+	return v.Reason
+}
+
+func violation(reason string, format string, args ...interface{}) *Violation {
+	return &Violation{Reason: reason, Detail: fmt.Sprintf(format, args...)}
+}
+
+// Limits bound an amount, both limits are inclusive and optional
+type Limits struct {
+	Min *big.Int
+	Max *big.Int
+}
+
+// Policy filters the deposits of a route
+type Policy struct {
+	AllowedResources map[msg.ResourceId]bool
+	DeniedResources  map[msg.ResourceId]bool
+	Amounts          map[msg.ResourceId]Limits
+	DeniedSenders    [][]byte
+	DeniedRecipients [][]byte
+}
+
+// Policies holds the policies of the routes from a chain
+type Policies struct {
+	allowedDestinations map[msg.ChainId]bool
+	fallback            *Policy
+	routes              map[msg.ChainId]*Policy
+}
+
+type limitsConfig struct {
+	Min string `json:"min"`
+	Max string `json:"max"`
+}
+
+type policyConfig struct {
+	AllowedResources []string                `json:"allowedResources"`
+	DeniedResources  []string                `json:"deniedResources"`
+	Amounts          map[string]limitsConfig `json:"amounts"`
+	DeniedSenders    []string                `json:"deniedSenders"`
+	DeniedRecipients []string                `json:"deniedRecipients"`
+}
+
+type config struct {
+	AllowedDestinations []msg.ChainId           `json:"allowedDestinations"`
+	Default             *policyConfig           `json:"default"`
+	Routes              map[string]policyConfig `json:"routes"`
+}
+
+// Load reads the policies from a file (see package documentation)
+func Load(path string) (*Policies, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Parse constructs the policies from their configured form (see package documentation)
+func Parse(data []byte) (*Policies, error) {
+	var cfg config
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&cfg); err != nil {
+		return nil, err
+	}
+
+	p := &Policies{
+		allowedDestinations: make(map[msg.ChainId]bool),
+		routes:              make(map[msg.ChainId]*Policy),
+	}
+	for _, dest := range cfg.AllowedDestinations {
+		p.allowedDestinations[dest] = true
+	}
+	if cfg.Default != nil {
+		policy, err := parsePolicy(*cfg.Default)
+		if err != nil {
+			return nil, fmt.Errorf("invalid default policy: %w", err)
+		}
+		p.fallback = policy
+	}
+	for dest, pc := range cfg.Routes {
+		id, err := strconv.ParseUint(dest, 10, 8)
+		if err != nil {
+			return nil, fmt.Errorf("invalid destination chain ID %s", dest)
+		}
+		policy, err := parsePolicy(pc)
+		if err != nil {
+			return nil, fmt.Errorf("invalid policy of route to %s: %w", dest, err)
+		}
+		p.routes[msg.ChainId(id)] = policy
+	}
+	return p, nil
+}
+
+func parsePolicy(pc policyConfig) (*Policy, error) {
+	p := &Policy{
+		AllowedResources: make(map[msg.ResourceId]bool),
+		DeniedResources:  make(map[msg.ResourceId]bool),
+		Amounts:          make(map[msg.ResourceId]Limits),
+	}
+	for _, s := range pc.AllowedResources {
+		rId, err := parseResourceId(s)
+		if err != nil {
+			return nil, err
+		}
+		p.AllowedResources[rId] = true
+	}
+	for _, s := range pc.DeniedResources {
+		rId, err := parseResourceId(s)
+		if err != nil {
+			return nil, err
+		}
+		p.DeniedResources[rId] = true
+	}
+	for s, lc := range pc.Amounts {
+		rId, err := parseResourceId(s)
+		if err != nil {
+			return nil, err
+		}
+		var l Limits
+		if l.Min, err = parseAmount(lc.Min); err != nil {
+			return nil, err
+		}
+		if l.Max, err = parseAmount(lc.Max); err != nil {
+			return nil, err
+		}
+		if l.Min != nil && l.Max != nil && l.Min.Cmp(l.Max) > 0 {
+			return nil, fmt.Errorf("min amount of resource %s exceeds its max", s)
+		}
+		p.Amounts[rId] = l
+	}
+	for _, s := range pc.DeniedSenders {
+		addr, err := parseAddress(s)
+		if err != nil {
+			return nil, err
+		}
+		p.DeniedSenders = append(p.DeniedSenders, addr)
+	}
+	for _, s := range pc.DeniedRecipients {
+		addr, err := parseAddress(s)
+		if err != nil {
+			return nil, err
+		}
+		p.DeniedRecipients = append(p.DeniedRecipients, addr)
+	}
+	return p, nil
+}
+
+func parseResourceId(s string) (msg.ResourceId, error) {
+	rIdBytes, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil || len(rIdBytes) != 32 {
+		return msg.ResourceId{}, fmt.Errorf("invalid resource ID %s", s)
+	}
+	return msg.ResourceIdFromSlice(rIdBytes), nil
+}
+
+func parseAmount(s string) (*big.Int, error) {
+	if s == "" {
+		return nil, nil
+	}
+	v, ok := new(big.Int).SetString(s, 10)
+	if !ok || v.Sign() < 0 {
+		return nil, fmt.Errorf("invalid amount %s", s)
+	}
+	return v, nil
+}
+
+func parseAddress(s string) ([]byte, error) {
+	if raw, err := hexutil.Decode(s); err == nil {
+		return canonical(raw), nil
+	}
+	if raw, err := (recipient.Substrate{}).Decode([]byte(s)); err == nil {
+		return raw, nil
+	}
+	return nil, fmt.Errorf("invalid address %s", s)
+}
+
+// canonical decodes hex and SS58 encoded addresses, so they can be compared with raw addresses
+func canonical(raw []byte) []byte {
+	if addr, err := (recipient.Ethereum{}).Decode(raw); err == nil {
+		return addr
+	}
+	if addr, err := (recipient.Substrate{}).Decode(raw); err == nil {
+		return addr
+	}
+	return raw
+}
+
+func contains(list [][]byte, raw []byte) bool {
+	addr := canonical(raw)
+	for _, a := range list {
+		if bytes.Equal(a, addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// policy returns the policy of the route to the destination, nil if there is none
+func (p *Policies) policy(dest msg.ChainId) *Policy {
+	if policy, ok := p.routes[dest]; ok {
+		return policy
+	}
+	return p.fallback
+}
+
+// Check returns a *Violation if the deposit must not be relayed. The sender may be nil if it is unknown.
+func (p *Policies) Check(m msg.Message, sender []byte) error {
+	if p == nil {
+		return nil
+	}
+	if len(p.allowedDestinations) != 0 && !p.allowedDestinations[m.Destination] {
+		return violation(ReasonDestinationDenied, "destination %d is not allowed", m.Destination)
+	}
+	policy := p.policy(m.Destination)
+	if policy == nil {
+		return nil
+	}
+	return policy.Check(m, sender)
+}
+
+// Check returns a *Violation if the deposit must not be relayed. The sender may be nil if it is unknown.
+func (p *Policy) Check(m msg.Message, sender []byte) error {
+	if len(p.AllowedResources) != 0 && !p.AllowedResources[m.ResourceId] {
+		return violation(ReasonResourceDenied, "resource %s is not allowed", m.ResourceId.Hex())
+	}
+	if p.DeniedResources[m.ResourceId] {
+		return violation(ReasonResourceDenied, "resource %s is denied", m.ResourceId.Hex())
+	}
+	if sender != nil && contains(p.DeniedSenders, sender) {
+		return violation(ReasonSenderDenied, "sender %s is denied", hexutil.Encode(sender))
+	}
+
+	var amounts []*big.Int
+	var rec []byte
+	switch m.Type {
+	case msg.FungibleTransfer:
+		if f, err := payload.ParseFungible(m); err == nil {
+			amounts, rec = []*big.Int{f.Amount}, f.Recipient
+		}
+	case msg.NonFungibleTransfer:
+		if nf, err := payload.ParseNonFungible(m); err == nil {
+			rec = nf.Recipient
+		}
+	case payload.SemiFungibleTransfer:
+		if sf, err := payload.ParseSemiFungible(m); err == nil {
+			amounts, rec = sf.Amounts, sf.Recipient
+		}
+	}
+
+	if rec != nil && contains(p.DeniedRecipients, rec) {
+		return violation(ReasonRecipientDenied, "recipient %s is denied", hexutil.Encode(rec))
+	}
+	if limits, ok := p.Amounts[m.ResourceId]; ok {
+		for _, amount := range amounts {
+			if limits.Min != nil && amount.Cmp(limits.Min) < 0 {
+				return violation(ReasonAmountBelowMin, "amount %s is below %s", amount, limits.Min)
+			}
+			if limits.Max != nil && amount.Cmp(limits.Max) > 0 {
+				return violation(ReasonAmountAboveMax, "amount %s is above %s", amount, limits.Max)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ChainSafe/ChainBridge/chains/deadletter"
+	"github.com/centrifuge/chainbridge-utils/msg"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/vedhavyas/go-subkey/v2"
+)
+
+var _ deadletter.Rejection = &Violation{}
+
+func TestCheck(t *testing.T) {
+	token := msg.ResourceId{31: 1}
+	nft := msg.ResourceId{31: 2}
+	denied := msg.ResourceId{31: 3}
+	sender := common.HexToAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	recipient := common.HexToAddress("0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359")
+	alice := "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"
+	_, alicePubKey, err := subkey.SS58Decode(alice)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	policies, err := Parse([]byte(fmt.Sprintf(`{
+		"allowedDestinations": [1, 2],
+		"default": {
+			"deniedResources": ["0x%x"],
+			"amounts": {"0x%x": {"min": "10", "max": "1000"}},
+			"deniedSenders": ["%s"],
+			"deniedRecipients": ["%s", "%s"]
+		},
+		"routes": {"2": {"allowedResources": ["0x%x"]}}
+	}`, denied, token, sender.Hex(), recipient.Hex(), alice, nft)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	other := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	testCases := []struct {
+		name   string
+		m      msg.Message
+		sender []byte
+		reason string
+	}{
+		{
+			name: "allowed",
+			m:    msg.NewFungibleTransfer(0, 1, 1, big.NewInt(100), token, other.Bytes()),
+		},
+		{
+			name:   "destination not allowed",
+			m:      msg.NewFungibleTransfer(0, 3, 1, big.NewInt(100), token, other.Bytes()),
+			reason: ReasonDestinationDenied,
+		},
+		{
+			name:   "resource denied",
+			m:      msg.NewFungibleTransfer(0, 1, 1, big.NewInt(100), denied, other.Bytes()),
+			reason: ReasonResourceDenied,
+		},
+		{
+			name:   "resource not allowed on route",
+			m:      msg.NewFungibleTransfer(0, 2, 1, big.NewInt(100), token, other.Bytes()),
+			reason: ReasonResourceDenied,
+		},
+		{
+			name: "route policy replaces default",
+			m:    msg.NewNonFungibleTransfer(0, 2, 1, nft, big.NewInt(1), recipient.Bytes(), nil),
+		},
+		{
+			name:   "amount below min",
+			m:      msg.NewFungibleTransfer(0, 1, 1, big.NewInt(9), token, other.Bytes()),
+			reason: ReasonAmountBelowMin,
+		},
+		{
+			name:   "amount above max",
+			m:      msg.NewFungibleTransfer(0, 1, 1, big.NewInt(1001), token, other.Bytes()),
+			reason: ReasonAmountAboveMax,
+		},
+		{
+			name:   "sender denied",
+			m:      msg.NewFungibleTransfer(0, 1, 1, big.NewInt(100), token, other.Bytes()),
+			sender: sender.Bytes(),
+			reason: ReasonSenderDenied,
+		},
+		{
+			name:   "hex encoded recipient denied",
+			m:      msg.NewFungibleTransfer(0, 1, 1, big.NewInt(100), token, []byte(recipient.Hex())),
+			reason: ReasonRecipientDenied,
+		},
+		{
+			name:   "substrate recipient denied",
+			m:      msg.NewNonFungibleTransfer(0, 1, 1, nft, big.NewInt(1), alicePubKey, nil),
+			reason: ReasonRecipientDenied,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := policies.Check(tc.m, tc.sender)
+			if tc.reason == "" {
+				if err != nil {
+					t.Fatalf("unexpected violation: %s", err)
+				}
+				return
+			}
+			var v *Violation
+			if !errors.As(err, &v) || v.Reason != tc.reason {
+				t.Fatalf("Got: %v Expected reason: %s", err, tc.reason)
+			}
+		})
+	}
+
+	var none *Policies
+	if err := none.Check(msg.NewFungibleTransfer(0, 3, 1, big.NewInt(1), denied, other.Bytes()), nil); err != nil {
+		t.Fatalf("unexpected violation without policies: %s", err)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	invalid := []string{
+		`{"unknown": true}`,
+		`{"routes": {"x": {}}}`,
+		`{"default": {"deniedResources": ["0x01"]}}`,
+		`{"default": {"deniedSenders": ["not an address"]}}`,
+		fmt.Sprintf(`{"default": {"amounts": {"0x%x": {"min": "2", "max": "1"}}}}`, msg.ResourceId{}),
+		fmt.Sprintf(`{"default": {"amounts": {"0x%x": {"max": "-1"}}}}`, msg.ResourceId{}),
+	}
+	for _, s := range invalid {
+		if _, err := Parse([]byte(s)); err == nil {
+			t.Errorf("expected error for %s", s)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+/*
+The recipient package validates the recipients of deposits for their destination chain. Recipients are taken as raw
+bytes from deposit records, so a deposit may carry a recipient that cannot exist on the destination chain (eg. a
+20 byte address aimed at a substrate chain). Writers decode recipients with the codec of their chain type before
+voting, and reject deposits with invalid recipients.
+*/
+package recipient
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/vedhavyas/go-subkey/v2"
+)
+
+var ErrInvalidRecipient = errors.New("invalid recipient")
+
+// Codec decodes raw recipients into the canonical form of a chain type
+type Codec interface {
+	// Decode returns the canonical recipient, or an error wrapping ErrInvalidRecipient
+	Decode(raw []byte) ([]byte, error)
+}
+
+var _ Codec = Substrate{}
+var _ Codec = Ethereum{}
+
+// SubstrateAccountLength is the length of a substrate AccountId32
+const SubstrateAccountLength = 32
+
+// Substrate accepts 32 byte account IDs, as well as SS58 encoded addresses
+type Substrate struct {
+	Network *uint16 // SS58 network prefix addresses must be encoded with, any network is accepted if nil
+}
+
+func (s Substrate) Decode(raw []byte) ([]byte, error) {
+	if len(raw) == SubstrateAccountLength {
+		return raw, nil
+	}
+
+	// Any other length must be an SS58 address. These are 47 to 50 characters for 32 byte accounts.
+	network, pubKey, err := subkey.SS58Decode(string(raw))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %d bytes is neither an account ID nor an SS58 address", ErrInvalidRecipient, len(raw))
+	}
+	if s.Network != nil && network != *s.Network {
+		return nil, fmt.Errorf("%w: SS58 address of network %d, expected network %d", ErrInvalidRecipient, network, *s.Network)
+	}
+	if len(pubKey) != SubstrateAccountLength {
+		return nil, fmt.Errorf("%w: SS58 address of a %d byte account", ErrInvalidRecipient, len(pubKey))
+	}
+	return pubKey, nil
+}
+
+// Ethereum accepts 20 byte addresses, as well as hex encoded addresses. Mixed case hex addresses must have a valid
+// EIP-55 checksum.
+type Ethereum struct{}
+
+func (Ethereum) Decode(raw []byte) ([]byte, error) {
+	if len(raw) == common.AddressLength {
+		return raw, nil
+	}
+
+	s := string(raw)
+	if !common.IsHexAddress(s) {
+		return nil, fmt.Errorf("%w: %d bytes is neither an address nor a hex encoded address", ErrInvalidRecipient, len(raw))
+	}
+	addr := common.HexToAddress(s)
+	hexPart := strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+	if hexPart != strings.ToLower(hexPart) && hexPart != strings.ToUpper(hexPart) && addr.Hex()[2:] != hexPart {
+		return nil, fmt.Errorf("%w: invalid checksum of address %s", ErrInvalidRecipient, s)
+	}
+	return addr.Bytes(), nil
+}
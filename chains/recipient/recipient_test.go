@@ -0,0 +1,65 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package recipient
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	bz, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bz
+}
+
+func TestCodecs(t *testing.T) {
+	alice := mustDecodeHex(t, "d43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d")
+	addr := mustDecodeHex(t, "5aaeb6053f3e94c9b9a09f33669435e7ef1beaed")
+	substrate, polkadot := uint16(42), uint16(0)
+
+	testCases := []struct {
+		name     string
+		codec    Codec
+		raw      []byte
+		expected []byte
+	}{
+		{name: "substrate account", codec: Substrate{}, raw: alice, expected: alice},
+		{name: "substrate ss58", codec: Substrate{}, raw: []byte("5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"), expected: alice},
+		{name: "substrate ss58 of network", codec: Substrate{Network: &substrate}, raw: []byte("5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"), expected: alice},
+		{name: "substrate ss58 of other network", codec: Substrate{Network: &polkadot}, raw: []byte("5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY")},
+		{name: "substrate account of network", codec: Substrate{Network: &polkadot}, raw: alice, expected: alice},
+		{name: "substrate invalid ss58", codec: Substrate{}, raw: []byte("5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQZ")},
+		{name: "substrate ethereum address", codec: Substrate{}, raw: addr},
+		{name: "substrate empty", codec: Substrate{}, raw: []byte{}},
+		{name: "ethereum address", codec: Ethereum{}, raw: addr, expected: addr},
+		{name: "ethereum hex", codec: Ethereum{}, raw: []byte("0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"), expected: addr},
+		{name: "ethereum checksum", codec: Ethereum{}, raw: []byte("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"), expected: addr},
+		{name: "ethereum invalid checksum", codec: Ethereum{}, raw: []byte("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD")},
+		{name: "ethereum substrate account", codec: Ethereum{}, raw: alice},
+		{name: "ethereum empty", codec: Ethereum{}, raw: []byte{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := tc.codec.Decode(tc.raw)
+			if tc.expected == nil {
+				if !errors.Is(err, ErrInvalidRecipient) {
+					t.Fatalf("Got: %v Expected: %v", err, ErrInvalidRecipient)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(res, tc.expected) {
+				t.Fatalf("Got: %x Expected: %x", res, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+/*
+The store package converts messages to and from the form in which they are written to disk, for the stores that keep
+messages across restarts.
+*/
+package store
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/centrifuge/chainbridge-utils/msg"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Message is a msg.Message as written to disk. As the payload fields have no type information in JSON, a field is a
+// list of ints if Ints is set (even if empty), and bytes otherwise.
+type Message struct {
+	Source       msg.ChainId      `json:"source"`
+	Destination  msg.ChainId      `json:"destination"`
+	Type         msg.TransferType `json:"type"`
+	DepositNonce msg.Nonce        `json:"depositNonce"`
+	ResourceId   hexutil.Bytes    `json:"resourceId"`
+	Payload      []Field          `json:"payload"`
+}
+
+// Field is a field of a payload
+type Field struct {
+	Bytes hexutil.Bytes  `json:"bytes,omitempty"`
+	Ints  []*hexutil.Big `json:"ints"`
+}
+
+// EncodeMessage converts the message to its stored form. Payload fields must be []byte or []*big.Int.
+func EncodeMessage(m msg.Message) (Message, error) {
+	res := Message{
+		Source:       m.Source,
+		Destination:  m.Destination,
+		Type:         m.Type,
+		DepositNonce: m.DepositNonce,
+		ResourceId:   m.ResourceId[:],
+		Payload:      make([]Field, len(m.Payload)),
+	}
+	for i, p := range m.Payload {
+		switch v := p.(type) {
+		case []byte:
+			res.Payload[i].Bytes = v
+		case []*big.Int:
+			res.Payload[i].Ints = make([]*hexutil.Big, len(v))
+			for j := range v {
+				res.Payload[i].Ints[j] = (*hexutil.Big)(v[j])
+			}
+		default:
+			return Message{}, fmt.Errorf("unsupported type %T of payload field %d", p, i)
+		}
+	}
+	return res, nil
+}
+
+// Decode converts the message back from its stored form
+func (m Message) Decode() (msg.Message, error) {
+	if len(m.ResourceId) != 32 {
+		return msg.Message{}, fmt.Errorf("invalid resource ID %s", m.ResourceId)
+	}
+	res := msg.Message{
+		Source:       m.Source,
+		Destination:  m.Destination,
+		Type:         m.Type,
+		DepositNonce: m.DepositNonce,
+		ResourceId:   msg.ResourceIdFromSlice(m.ResourceId),
+		Payload:      make([]interface{}, len(m.Payload)),
+	}
+	for i, f := range m.Payload {
+		if f.Ints != nil {
+			ints := make([]*big.Int, len(f.Ints))
+			for j := range f.Ints {
+				ints[j] = f.Ints[j].ToInt()
+			}
+			res.Payload[i] = ints
+		} else {
+			bz := []byte(f.Bytes)
+			if bz == nil {
+				bz = []byte{}
+			}
+			res.Payload[i] = bz
+		}
+	}
+	return res, nil
+}
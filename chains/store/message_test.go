@@ -0,0 +1,51 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package store
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ChainSafe/ChainBridge/chains/payload"
+	"github.com/centrifuge/chainbridge-utils/msg"
+)
+
+func TestMessageRoundTrip(t *testing.T) {
+	rId := msg.ResourceId{31: 1}
+	sf := &payload.SemiFungible{
+		TokenIds:  []*big.Int{big.NewInt(1), big.NewInt(2)},
+		Amounts:   []*big.Int{big.NewInt(10), big.NewInt(20)},
+		Recipient: []byte{0xab},
+		Data:      []byte{},
+	}
+	messages := []msg.Message{
+		msg.NewFungibleTransfer(1, 2, 3, big.NewInt(1000), rId, []byte{0xab}),
+		msg.NewNonFungibleTransfer(1, 2, 3, rId, big.NewInt(1), []byte{0xab}, []byte{}),
+		msg.NewGenericTransfer(1, 2, 3, rId, []byte{0xde, 0xad}),
+		sf.Message(1, 2, 3, rId),
+	}
+	for _, m := range messages {
+		encoded, err := EncodeMessage(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := json.Marshal(encoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var stored Message
+		if err = json.Unmarshal(data, &stored); err != nil {
+			t.Fatal(err)
+		}
+		res, err := stored.Decode()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(res, m) {
+			t.Errorf("Got: %#v Expected: %#v", res, m)
+		}
+	}
+}
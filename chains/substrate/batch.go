@@ -0,0 +1,184 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package substrate
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	utils "github.com/ChainSafe/ChainBridge/shared/substrate"
+	"github.com/ChainSafe/log15"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// Utility.batch dispatches calls until the first one fails. Utility.batch_all is not used, as a single failing
+// acknowledgement would revert the acknowledgements of all other messages in the batch.
+const (
+	BatchMethod           = "Utility.batch"
+	BatchCompletedEvent   = "Utility.BatchCompleted"
+	BatchInterruptedEvent = "Utility.BatchInterrupted"
+)
+
+// DefaultAckBatchSize is the maximum number of acknowledgements submitted in one batch
+const DefaultAckBatchSize = 16
+
+// ErrBatchInterrupted is returned for calls that were not dispatched, as an earlier call of the batch failed
+var ErrBatchInterrupted = errors.New("batch interrupted before call was dispatched")
+
+// batchRequest is a call waiting to be submitted as part of a batch
+type batchRequest struct {
+	call   types.Call
+	result chan error
+}
+
+// batcher collects calls over a short window and submits them as a single Utility.batch extrinsic.
+// The result of each call is reported back to its submitter.
+type batcher struct {
+	conn     *Connection
+	log      log15.Logger
+	window   time.Duration // Time to wait for further calls after the first call of a batch arrived
+	size     int           // Maximum number of calls per batch
+	requests chan *batchRequest
+	stop     <-chan int
+}
+
+func newBatcher(conn *Connection, log log15.Logger, window time.Duration, size int) *batcher {
+	return &batcher{
+		conn:     conn,
+		log:      log,
+		window:   window,
+		size:     size,
+		requests: make(chan *batchRequest),
+		stop:     conn.stop,
+	}
+}
+
+// submit constructs the call and waits until the batch it was included in has been submitted
+func (b *batcher) submit(method utils.Method, args ...interface{}) error {
+	meta := b.conn.getMetadata()
+	call, err := types.NewCall(&meta, string(method), args...)
+	if err != nil {
+		return fmt.Errorf("failed to construct call: %w", err)
+	}
+
+	req := &batchRequest{call: call, result: make(chan error, 1)}
+	select {
+	case b.requests <- req:
+	case <-b.stop:
+		return TerminatedError
+	}
+
+	select {
+	case err := <-req.result:
+		return err
+	case <-b.stop:
+		return TerminatedError
+	}
+}
+
+// run collects calls until the window passes or the batch is full, then submits them. This blocks until the
+// system is stopped.
+func (b *batcher) run() {
+	for {
+		select {
+		case <-b.stop:
+			return
+		case req := <-b.requests:
+			pending := []*batchRequest{req}
+			timer := time.NewTimer(b.window)
+		collect:
+			for len(pending) < b.size {
+				select {
+				case req := <-b.requests:
+					pending = append(pending, req)
+				case <-timer.C:
+					break collect
+				case <-b.stop:
+					timer.Stop()
+					return
+				}
+			}
+			timer.Stop()
+
+			results := b.submitBatch(pending)
+			for i, req := range pending {
+				req.result <- results[i]
+			}
+		}
+	}
+}
+
+// submitBatch submits the calls and returns the result of each call. A single call is submitted without a batch.
+func (b *batcher) submitBatch(pending []*batchRequest) []error {
+	results := make([]error, len(pending))
+	fail := func(err error) []error {
+		for i := range results {
+			results[i] = err
+		}
+		return results
+	}
+
+	meta, rv := b.conn.getRuntime()
+	if len(pending) == 1 {
+		_, err := b.conn.submitCall(pending[0].call, &meta, &rv)
+		return fail(err)
+	}
+
+	calls := make([]types.Call, len(pending))
+	for i, req := range pending {
+		calls[i] = req.call
+	}
+
+	batch, err := types.NewCall(&meta, BatchMethod, calls)
+	if err != nil {
+		return fail(fmt.Errorf("failed to construct batch call: %w", err))
+	}
+
+	b.log.Debug("Submitting batch call", "calls", len(calls))
+	events, err := b.conn.submitCall(batch, &meta, &rv)
+	if err != nil {
+		return fail(err)
+	}
+	return batchResults(&meta, events, len(calls))
+}
+
+// batchResults maps the events of a Utility.batch extrinsic to the results of its n calls
+func batchResults(meta *types.Metadata, events []*parser.Event, n int) []error {
+	results := make([]error, n)
+	for _, event := range events {
+		switch event.Name {
+		case BatchCompletedEvent:
+			return results
+		case BatchInterruptedEvent:
+			var index = -1
+			for _, field := range event.Fields {
+				if i, ok := field.Value.(types.U32); ok {
+					index = int(i)
+				}
+			}
+			dispatchErr, ok := findDispatchError(event.Fields)
+			if index < 0 || !ok {
+				break
+			}
+
+			for i := range results {
+				switch {
+				case i == index:
+					results[i] = newExtrinsicFailedError(meta, dispatchErr)
+				case i > index:
+					results[i] = ErrBatchInterrupted
+				}
+			}
+			return results
+		}
+	}
+
+	err := errors.New("no batch result event found")
+	for i := range results {
+		results[i] = err
+	}
+	return results
+}
@@ -0,0 +1,58 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package substrate
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+func TestBatchResults(t *testing.T) {
+	meta := &types.Metadata{}
+
+	// All calls succeeded
+	events := []*parser.Event{
+		{Name: "Utility.ItemCompleted"},
+		{Name: "Utility.ItemCompleted"},
+		{Name: BatchCompletedEvent},
+		{Name: ExtrinsicSuccessEvent},
+	}
+	for i, err := range batchResults(meta, events, 2) {
+		if err != nil {
+			t.Fatalf("call %d: unexpected error: %s", i, err)
+		}
+	}
+
+	// Second call failed, the third was not dispatched
+	events = []*parser.Event{
+		{Name: "Utility.ItemCompleted"},
+		{Name: BatchInterruptedEvent, Fields: registry.DecodedFields{
+			&registry.DecodedField{Name: "index", Value: types.U32(1)},
+			&registry.DecodedField{Name: "error", Value: types.DispatchError{IsBadOrigin: true}},
+		}},
+		{Name: ExtrinsicSuccessEvent},
+	}
+	results := batchResults(meta, events, 3)
+	if results[0] != nil {
+		t.Fatalf("call 0: unexpected error: %s", results[0])
+	}
+	var failedErr *ExtrinsicFailedError
+	if !errors.As(results[1], &failedErr) || failedErr.Name != "BadOrigin" {
+		t.Fatalf("call 1: expected BadOrigin, got: %v", results[1])
+	}
+	if !errors.Is(results[2], ErrBatchInterrupted) {
+		t.Fatalf("call 2: expected ErrBatchInterrupted, got: %v", results[2])
+	}
+
+	// No result event
+	for i, err := range batchResults(meta, []*parser.Event{{Name: ExtrinsicSuccessEvent}}, 2) {
+		if err == nil {
+			t.Fatalf("call %d: expected error", i)
+		}
+	}
+}
@@ -3,7 +3,8 @@
 
 /*
 The substrate package contains the logic for interacting with substrate chains.
-The current supported transfer types are Fungible, Nonfungible, and generic.
+The current supported transfer types are Fungible, Nonfungible, and generic. Semi-fungible transfers (eg. ERC1155)
+are supported as destination only.
 
 There are 3 major components: the connection, the listener, and the writer.
 
@@ -14,7 +15,7 @@ It also handles state queries. The connection is shared by the writer and listen
 
 # Listener
 
-The substrate listener polls blocks and parses the associated events for the three transfer types. It then forwards these into the router.
+The substrate listener follows the finalized heads of the chain and parses the events of each finalized block for the three transfer types. It then forwards these into the router. Events are decoded with the metadata of the runtime that applied at their block, so blocks produced before a runtime upgrade can still be processed.
 
 # Writer
 
@@ -23,7 +24,9 @@ As the writer receives messages from the router, it constructs proposals. If a p
 package substrate
 
 import (
-	"fmt"
+	"github.com/ChainSafe/ChainBridge/chains/approval"
+	"github.com/ChainSafe/ChainBridge/chains/breaker"
+	"github.com/ChainSafe/ChainBridge/chains/deadletter"
 	"github.com/ChainSafe/log15"
 	"github.com/centrifuge/chainbridge-utils/blockstore"
 	"github.com/centrifuge/chainbridge-utils/core"
@@ -31,10 +34,6 @@ import (
 	"github.com/centrifuge/chainbridge-utils/keystore"
 	metrics "github.com/centrifuge/chainbridge-utils/metrics/types"
 	"github.com/centrifuge/chainbridge-utils/msg"
-	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
-	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/retriever"
-	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/state"
-	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 )
 
 var _ core.Chain = &Chain{}
@@ -44,6 +43,7 @@ type Chain struct {
 	conn     *Connection       // THe chains connection
 	listener *listener         // The listener of this chain
 	writer   *writer           // The writer of the chain
+	resolver core.Writer       // Resolves messages routed to this chain, the writer wrapped by any circuit breaker and approval queue
 	stop     chan<- int
 }
 
@@ -62,8 +62,6 @@ func checkBlockstore(bs *blockstore.Blockstore, startBlock uint64) (uint64, erro
 	}
 }
 
-const U256LookupIndex = 89
-
 func InitializeChain(cfg *core.ChainConfig, logger log15.Logger, sysErr chan<- error, m *metrics.ChainMetrics) (*Chain, error) {
 	kp, err := keystore.KeypairFromAddress(cfg.From, keystore.SubChain, cfg.KeystorePath, cfg.Insecure)
 	if err != nil {
@@ -88,6 +86,11 @@ func InitializeChain(cfg *core.ChainConfig, logger log15.Logger, sysErr chan<- e
 	stop := make(chan int)
 	// Setup connection
 	conn := NewConnection(cfg.Endpoint, cfg.Name, krp, logger, stop, sysErr)
+	conn.names = parseBridgeNames(cfg)
+	conn.mortalPeriod = parseMortalPeriod(cfg)
+	conn.tip = parseTipPolicy(cfg)
+	conn.waitForFinality = parseWaitForFinality(cfg)
+	conn.proxied = parseProxiedAccount(cfg)
 	err = conn.Connect()
 	if err != nil {
 		return nil, err
@@ -108,26 +111,65 @@ func InitializeChain(cfg *core.ChainConfig, logger log15.Logger, sysErr chan<- e
 
 	ue := parseUseExtended(cfg)
 
-	// u256 is represented as [u64;4]. We use this override to skip extra processing when decoding fields with this type.
-	u256FieldOverride := registry.FieldOverride{
-		FieldLookupIndex: U256LookupIndex,
-		FieldDecoder:     &registry.ValueDecoder[types.U256]{},
+	// Setup listener & writer
+	l := NewListener(conn, cfg.Name, cfg.Id, startBlock, logger, bs, stop, sysErr, m, conn.eventRetriever)
+	l.fetchWorkers = parseFetchWorkers(cfg)
+	l.fetchWindow = parseFetchWindow(cfg)
+	dl, err := deadletter.NewStore(cfg.BlockstorePath, cfg.Id, kp.Address())
+	if err != nil {
+		return nil, err
 	}
-
-	eventRetriever, err := retriever.NewDefaultEventRetriever(state.NewEventProvider(conn.api.RPC.State), conn.api.RPC.State, u256FieldOverride)
-
+	w := NewWriter(conn, logger, sysErr, m, ue, dl)
+	w.interrupted, err = newInterruptedStore(cfg.BlockstorePath, cfg.Id, kp.Address())
 	if err != nil {
-		return nil, fmt.Errorf("event retriever creation: %w", err)
+		return nil, err
+	}
+	w.decimals = parseResourceDecimals(cfg)
+	w.network = conn.ss58Prefix()
+	w.resources = newResourceCache(parseResourceCacheTTL(cfg))
+	if m != nil {
+		w.resources.registerMetrics(cfg.Name)
+	}
+	l.resources = w.resources
+	l.resourceEvents = parseResourceEvents(cfg, conn.names.pallet)
+	l.policies = parseDepositPolicies(cfg)
+	l.deadLetters = dl
+	if window := parseAckBatchWindow(cfg); window > 0 {
+		w.batcher = newBatcher(conn, logger, window, parseAckBatchSize(cfg))
+	}
+
+	var resolver core.Writer = w
+	if limits := parseVolumeLimits(cfg); limits != nil {
+		// The bridge pallet cannot be paused, halting the routes is all the breaker can do. It is wrapped by the
+		// approval queue, so transfers count towards the volume once released.
+		b, err := breaker.NewBreaker(resolver, limits, nil, cfg.BlockstorePath, cfg.Id, kp.Address(), logger)
+		if err != nil {
+			return nil, err
+		}
+		if m != nil {
+			b.RegisterMetrics(cfg.Name)
+		}
+		breaker.Register(b)
+		resolver = b
+	}
+	if thresholds := parseApprovalThresholds(cfg); thresholds != nil {
+		queue, err := approval.NewQueue(resolver, thresholds, cfg.BlockstorePath, cfg.Id, kp.Address(), logger)
+		if err != nil {
+			return nil, err
+		}
+		if m != nil {
+			queue.RegisterMetrics(cfg.Name)
+		}
+		approval.Register(queue)
+		resolver = queue
 	}
 
-	// Setup listener & writer
-	l := NewListener(conn, cfg.Name, cfg.Id, startBlock, logger, bs, stop, sysErr, m, eventRetriever)
-	w := NewWriter(conn, logger, sysErr, m, ue)
 	return &Chain{
 		cfg:      cfg,
 		conn:     conn,
 		listener: l,
 		writer:   w,
+		resolver: resolver,
 		stop:     stop,
 	}, nil
 }
@@ -137,12 +179,13 @@ func (c *Chain) Start() error {
 	if err != nil {
 		return err
 	}
+	c.writer.start()
 	c.conn.log.Debug("Successfully started chain", "chainId", c.cfg.Id)
 	return nil
 }
 
 func (c *Chain) SetRouter(r *core.Router) {
-	r.Listen(c.cfg.Id, c.writer)
+	r.Listen(c.cfg.Id, c.resolver)
 	c.listener.setRouter(r)
 }
 
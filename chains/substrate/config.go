@@ -4,13 +4,104 @@
 package substrate
 
 import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/ChainSafe/ChainBridge/chains/approval"
+	"github.com/ChainSafe/ChainBridge/chains/breaker"
+	"github.com/ChainSafe/ChainBridge/chains/decimals"
+	"github.com/ChainSafe/ChainBridge/chains/policy"
+	utils "github.com/ChainSafe/ChainBridge/shared/substrate"
 	"github.com/centrifuge/chainbridge-utils/core"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/vedhavyas/go-subkey/v2"
 )
 
+// Chain specific options
+var (
+	StartBlockOpt               = "startBlock"
+	UseExtendedCallOpt          = "useExtendedCall"
+	BridgePalletNameOpt         = "bridgePalletName"
+	BridgeStoragePrefixOpt      = "bridgeStoragePrefix"
+	FungibleTransferEventOpt    = "fungibleTransferEvent"
+	NonFungibleTransferEventOpt = "nonFungibleTransferEvent"
+	GenericTransferEventOpt     = "genericTransferEvent"
+	AmountFieldOpt              = "amountFieldName"
+	BytesFieldOpt               = "bytesFieldName"
+	MortalPeriodOpt             = "mortalPeriod"
+	TipOpt                      = "tip"
+	TipFeeMultiplierOpt         = "tipFeeMultiplier"
+	WaitForFinalityOpt          = "waitForFinality"
+	FetchWorkersOpt             = "blockFetchWorkers"
+	FetchWindowOpt              = "blockFetchWindow"
+	ProxiedAccountOpt           = "proxiedAccount"
+	AckBatchWindowOpt           = "ackBatchWindow"
+	AckBatchSizeOpt             = "ackBatchSize"
+	ResourceCacheTTLOpt         = "resourceCacheTTL"
+	ResourceEventsOpt           = "resourceEvents"
+	ResourceDecimalsOpt         = "resourceDecimals"
+	DepositPoliciesOpt          = "depositPolicies"
+	ApprovalThresholdsOpt       = "approvalThresholds"
+	VolumeLimitsOpt             = "volumeLimits"
+)
+
+// Default names of the event fields decoded by the listener
+const (
+	DefaultAmountField = "primitive_types.U256.U256"
+	DefaultBytesField  = "Vec<u8>"
+)
+
+// bridgeNames maps the names used by the relayer onto the bridge pallet of the target runtime
+type bridgeNames struct {
+	pallet              string    // Name of the bridge pallet, used for calls and constants
+	storagePrefix       string    // Storage prefix of the bridge pallet
+	fungibleTransfer    eventName // Full name of the fungible transfer event
+	nonFungibleTransfer eventName // Full name of the non-fungible transfer event
+	genericTransfer     eventName // Full name of the generic transfer event
+	amountField         string    // Name of the amount field in transfer events
+	bytesField          string    // Name of the recipient/metadata field in transfer events
+}
+
+func defaultBridgeNames() bridgeNames {
+	return newBridgeNames(utils.BridgePalletName, utils.BridgeStoragePrefix)
+}
+
+func newBridgeNames(pallet, storagePrefix string) bridgeNames {
+	return bridgeNames{
+		pallet:              pallet,
+		storagePrefix:       storagePrefix,
+		fungibleTransfer:    eventName(pallet + ".FungibleTransfer"),
+		nonFungibleTransfer: eventName(pallet + ".NonFungibleTransfer"),
+		genericTransfer:     eventName(pallet + ".GenericTransfer"),
+		amountField:         DefaultAmountField,
+		bytesField:          DefaultBytesField,
+	}
+}
+
+// extendedCalls determines which proposal calls are extended with the encoded resource ID. This is required for
+// methods of the example pallet, and is kept for backward compatibility.
+type extendedCalls struct {
+	all       bool              // Extend all calls
+	resources map[[32]byte]bool // Resource IDs whose calls are extended
+	methods   map[string]bool   // Methods whose calls are extended, eg. Example.transfer
+}
+
+// extends returns true if the call of the resource's method is extended with the resource ID
+func (e extendedCalls) extends(id [32]byte, method string) bool {
+	return e.all || e.resources[id] || e.methods[method]
+}
+
+// method returns the fully qualified name of a bridge pallet call
+func (n bridgeNames) method(call string) utils.Method {
+	return utils.Method(n.pallet + "." + call)
+}
+
 func parseStartBlock(cfg *core.ChainConfig) uint64 {
-	if blk, ok := cfg.Opts["startBlock"]; ok {
+	if blk, ok := cfg.Opts[StartBlockOpt]; ok {
 		res, err := strconv.ParseUint(blk, 10, 32)
 		if err != nil {
 			panic(err)
@@ -20,8 +111,44 @@ func parseStartBlock(cfg *core.ChainConfig) uint64 {
 	return 0
 }
 
-func parseUseExtended(cfg *core.ChainConfig) bool {
-	if b, ok := cfg.Opts["useExtendedCall"]; ok {
+// parseUseExtended returns the calls extended with the resource ID. The option is either a bool applying to all
+// calls, or a comma separated list of resource IDs (0x prefixed hex) and method names (eg. Example.transfer).
+func parseUseExtended(cfg *core.ChainConfig) extendedCalls {
+	ext := extendedCalls{resources: make(map[[32]byte]bool), methods: make(map[string]bool)}
+	v, ok := cfg.Opts[UseExtendedCallOpt]
+	if !ok || v == "" {
+		return ext
+	}
+	if res, err := strconv.ParseBool(v); err == nil {
+		ext.all = res
+		return ext
+	}
+
+	for _, item := range strings.Split(v, ",") {
+		item = strings.TrimSpace(item)
+		switch {
+		case item == "":
+			continue
+		case strings.HasPrefix(item, "0x"):
+			id, err := hex.DecodeString(item[2:])
+			if err != nil || len(id) != 32 {
+				panic(fmt.Errorf("%s: invalid resource ID %s", UseExtendedCallOpt, item))
+			}
+			var rId [32]byte
+			copy(rId[:], id)
+			ext.resources[rId] = true
+		case strings.Contains(item, "."):
+			ext.methods[item] = true
+		default:
+			panic(fmt.Errorf("%s: expected bool, resource IDs or method names, got %s", UseExtendedCallOpt, item))
+		}
+	}
+	return ext
+}
+
+// parseWaitForFinality returns true if submitted extrinsics should be considered done only once finalized
+func parseWaitForFinality(cfg *core.ChainConfig) bool {
+	if b, ok := cfg.Opts[WaitForFinalityOpt]; ok {
 		res, err := strconv.ParseBool(b)
 		if err != nil {
 			panic(err)
@@ -30,3 +157,235 @@ func parseUseExtended(cfg *core.ChainConfig) bool {
 	}
 	return false
 }
+
+// parseBridgeNames reads the bridge pallet, storage, event and field names from the chain options.
+// Event names are given without the pallet prefix. The storage prefix defaults to the pallet name.
+func parseBridgeNames(cfg *core.ChainConfig) bridgeNames {
+	pallet := utils.BridgePalletName
+	if p, ok := cfg.Opts[BridgePalletNameOpt]; ok && p != "" {
+		pallet = p
+	}
+	storagePrefix := pallet
+	if p, ok := cfg.Opts[BridgeStoragePrefixOpt]; ok && p != "" {
+		storagePrefix = p
+	}
+
+	names := newBridgeNames(pallet, storagePrefix)
+	if e, ok := cfg.Opts[FungibleTransferEventOpt]; ok && e != "" {
+		names.fungibleTransfer = eventName(pallet + "." + e)
+	}
+	if e, ok := cfg.Opts[NonFungibleTransferEventOpt]; ok && e != "" {
+		names.nonFungibleTransfer = eventName(pallet + "." + e)
+	}
+	if e, ok := cfg.Opts[GenericTransferEventOpt]; ok && e != "" {
+		names.genericTransfer = eventName(pallet + "." + e)
+	}
+	if f, ok := cfg.Opts[AmountFieldOpt]; ok && f != "" {
+		names.amountField = f
+	}
+	if f, ok := cfg.Opts[BytesFieldOpt]; ok && f != "" {
+		names.bytesField = f
+	}
+	return names
+}
+
+// parseMortalPeriod returns the mortality period of extrinsics in blocks. Extrinsics are immortal by default.
+func parseMortalPeriod(cfg *core.ChainConfig) uint64 {
+	if p, ok := cfg.Opts[MortalPeriodOpt]; ok && p != "" {
+		res, err := strconv.ParseUint(p, 10, 32)
+		if err != nil {
+			panic(err)
+		}
+		if res != 0 && (res < MinMortalPeriod || res > MaxMortalPeriod) {
+			panic(fmt.Errorf("%s must be between %d and %d", MortalPeriodOpt, MinMortalPeriod, MaxMortalPeriod))
+		}
+		return res
+	}
+	return 0
+}
+
+// parseTipPolicy returns the tip policy for extrinsics. Without options a zero tip is used.
+func parseTipPolicy(cfg *core.ChainConfig) tipPolicy {
+	policy := tipPolicy{fixed: big.NewInt(0)}
+	if t, ok := cfg.Opts[TipOpt]; ok && t != "" {
+		tip, pass := new(big.Int).SetString(t, 10)
+		if !pass || tip.Sign() < 0 {
+			panic(fmt.Errorf("unable to parse %s", TipOpt))
+		}
+		policy.fixed = tip
+	}
+	if m, ok := cfg.Opts[TipFeeMultiplierOpt]; ok && m != "" {
+		multiplier, pass := new(big.Float).SetString(m)
+		if !pass || multiplier.Sign() < 0 {
+			panic(fmt.Errorf("unable to parse %s", TipFeeMultiplierOpt))
+		}
+		policy.feeMultiplier = multiplier
+	}
+	return policy
+}
+
+// parseFetchWorkers returns the number of blocks the listener fetches in parallel
+func parseFetchWorkers(cfg *core.ChainConfig) int {
+	if w, ok := cfg.Opts[FetchWorkersOpt]; ok && w != "" {
+		res, err := strconv.ParseUint(w, 10, 16)
+		if err != nil {
+			panic(err)
+		}
+		if res == 0 {
+			panic(fmt.Errorf("%s must be greater than 0", FetchWorkersOpt))
+		}
+		return int(res)
+	}
+	return DefaultFetchWorkers
+}
+
+// parseFetchWindow returns the maximum number of blocks the listener fetches ahead of processing
+func parseFetchWindow(cfg *core.ChainConfig) uint64 {
+	if w, ok := cfg.Opts[FetchWindowOpt]; ok && w != "" {
+		res, err := strconv.ParseUint(w, 10, 32)
+		if err != nil {
+			panic(err)
+		}
+		if res == 0 {
+			panic(fmt.Errorf("%s must be greater than 0", FetchWindowOpt))
+		}
+		return res
+	}
+	return DefaultFetchWindow
+}
+
+// parseProxiedAccount returns the account the relayer submits calls for through Proxy.proxy. The account may
+// be given as SS58 address or hex encoded public key. Returns nil if calls are submitted directly.
+func parseProxiedAccount(cfg *core.ChainConfig) *types.AccountID {
+	a, ok := cfg.Opts[ProxiedAccountOpt]
+	if !ok || a == "" {
+		return nil
+	}
+	if strings.HasPrefix(a, "0x") {
+		accountID, err := types.NewAccountIDFromHexString(a)
+		if err != nil {
+			panic(fmt.Errorf("unable to parse %s: %w", ProxiedAccountOpt, err))
+		}
+		return accountID
+	}
+	_, pubKey, err := subkey.SS58Decode(a)
+	if err != nil {
+		panic(fmt.Errorf("unable to parse %s: %w", ProxiedAccountOpt, err))
+	}
+	accountID, err := types.NewAccountID(pubKey)
+	if err != nil {
+		panic(fmt.Errorf("unable to parse %s: %w", ProxiedAccountOpt, err))
+	}
+	return accountID
+}
+
+// parseAckBatchWindow returns the time acknowledgements are collected for before they are submitted as a batch.
+// Batching is disabled by default.
+func parseAckBatchWindow(cfg *core.ChainConfig) time.Duration {
+	if w, ok := cfg.Opts[AckBatchWindowOpt]; ok && w != "" {
+		res, err := time.ParseDuration(w)
+		if err != nil {
+			panic(err)
+		}
+		if res < 0 {
+			panic(fmt.Errorf("%s must not be negative", AckBatchWindowOpt))
+		}
+		return res
+	}
+	return 0
+}
+
+// parseAckBatchSize returns the maximum number of acknowledgements submitted in one batch
+func parseAckBatchSize(cfg *core.ChainConfig) int {
+	if s, ok := cfg.Opts[AckBatchSizeOpt]; ok && s != "" {
+		res, err := strconv.ParseUint(s, 10, 16)
+		if err != nil {
+			panic(err)
+		}
+		if res == 0 {
+			panic(fmt.Errorf("%s must be greater than 0", AckBatchSizeOpt))
+		}
+		return int(res)
+	}
+	return DefaultAckBatchSize
+}
+
+// parseResourceCacheTTL returns the time resolved resource methods are cached for. A TTL of 0 disables the cache.
+func parseResourceCacheTTL(cfg *core.ChainConfig) time.Duration {
+	if t, ok := cfg.Opts[ResourceCacheTTLOpt]; ok && t != "" {
+		res, err := time.ParseDuration(t)
+		if err != nil {
+			panic(err)
+		}
+		if res < 0 {
+			panic(fmt.Errorf("%s must not be negative", ResourceCacheTTLOpt))
+		}
+		return res
+	}
+	return DefaultResourceCacheTTL
+}
+
+// parseResourceEvents returns the full names of the events that signal a change of the registered resources.
+// Event names are given as comma separated list, without the pallet prefix.
+func parseResourceEvents(cfg *core.ChainConfig, pallet string) []eventName {
+	events := DefaultResourceEvents
+	if e, ok := cfg.Opts[ResourceEventsOpt]; ok && e != "" {
+		events = strings.Split(e, ",")
+	}
+
+	var res []eventName
+	for _, e := range events {
+		if e = strings.TrimSpace(e); e != "" {
+			res = append(res, eventName(pallet+"."+e))
+		}
+	}
+	return res
+}
+
+// parseResourceDecimals returns the table used to convert fungible amounts to the decimals of this chain
+func parseResourceDecimals(cfg *core.ChainConfig) *decimals.Table {
+	if d, ok := cfg.Opts[ResourceDecimalsOpt]; ok && d != "" {
+		table, err := decimals.Parse(d)
+		if err != nil {
+			panic(fmt.Errorf("%s: %w", ResourceDecimalsOpt, err))
+		}
+		return table
+	}
+	return decimals.NewTable()
+}
+
+// parseDepositPolicies returns the policies filtering deposits before they are routed, nil if unset
+func parseDepositPolicies(cfg *core.ChainConfig) *policy.Policies {
+	if path, ok := cfg.Opts[DepositPoliciesOpt]; ok && path != "" {
+		policies, err := policy.Load(path)
+		if err != nil {
+			panic(fmt.Errorf("%s: %w", DepositPoliciesOpt, err))
+		}
+		return policies
+	}
+	return nil
+}
+
+// parseApprovalThresholds returns the amounts above which transfers wait for an operator, nil if unset
+func parseApprovalThresholds(cfg *core.ChainConfig) approval.Thresholds {
+	if thresholds, ok := cfg.Opts[ApprovalThresholdsOpt]; ok && thresholds != "" {
+		parsed, err := approval.ParseThresholds(thresholds)
+		if err != nil {
+			panic(fmt.Errorf("%s: %w", ApprovalThresholdsOpt, err))
+		}
+		return parsed
+	}
+	return nil
+}
+
+// parseVolumeLimits returns the volume limits of the routes to this chain, nil if unset
+func parseVolumeLimits(cfg *core.ChainConfig) *breaker.Limits {
+	if limits, ok := cfg.Opts[VolumeLimitsOpt]; ok && limits != "" {
+		parsed, err := breaker.ParseLimits(limits)
+		if err != nil {
+			panic(fmt.Errorf("%s: %w", VolumeLimitsOpt, err))
+		}
+		return parsed
+	}
+	return nil
+}
@@ -4,9 +4,14 @@
 package substrate
 
 import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/centrifuge/chainbridge-utils/core"
+	"github.com/centrifuge/chainbridge-utils/msg"
 )
 
 func TestParseStartBlock(t *testing.T) {
@@ -28,3 +33,233 @@ func TestParseStartBlock(t *testing.T) {
 		t.Fatalf("Got: %d Expected: %d", blk, 0)
 	}
 }
+
+func TestParseBridgeNames(t *testing.T) {
+	// Defaults when no options are provided
+	cfg := &core.ChainConfig{Opts: map[string]string{}}
+
+	names := parseBridgeNames(cfg)
+
+	if names != defaultBridgeNames() {
+		t.Fatalf("Got: %#v Expected: %#v", names, defaultBridgeNames())
+	}
+
+	// Renamed pallet, storage prefix follows the pallet name
+	cfg = &core.ChainConfig{Opts: map[string]string{
+		BridgePalletNameOpt:      "Bridge",
+		FungibleTransferEventOpt: "NativeTransfer",
+		AmountFieldOpt:           "U256",
+	}}
+
+	names = parseBridgeNames(cfg)
+
+	if names.pallet != "Bridge" || names.storagePrefix != "Bridge" {
+		t.Fatalf("Got: %s/%s Expected: Bridge/Bridge", names.pallet, names.storagePrefix)
+	}
+	if names.fungibleTransfer != "Bridge.NativeTransfer" {
+		t.Fatalf("Got: %s Expected: %s", names.fungibleTransfer, "Bridge.NativeTransfer")
+	}
+	if names.genericTransfer != "Bridge.GenericTransfer" {
+		t.Fatalf("Got: %s Expected: %s", names.genericTransfer, "Bridge.GenericTransfer")
+	}
+	if names.amountField != "U256" || names.bytesField != DefaultBytesField {
+		t.Fatalf("Got: %s/%s Expected: U256/%s", names.amountField, names.bytesField, DefaultBytesField)
+	}
+	if names.method(acknowledgeProposal) != "Bridge.acknowledge_proposal" {
+		t.Fatalf("Got: %s Expected: %s", names.method(acknowledgeProposal), "Bridge.acknowledge_proposal")
+	}
+
+	// Explicit storage prefix
+	cfg = &core.ChainConfig{Opts: map[string]string{
+		BridgePalletNameOpt:    "Bridge",
+		BridgeStoragePrefixOpt: "ChainBridge",
+	}}
+
+	names = parseBridgeNames(cfg)
+
+	if names.storagePrefix != "ChainBridge" {
+		t.Fatalf("Got: %s Expected: %s", names.storagePrefix, "ChainBridge")
+	}
+}
+
+func TestParseMortalPeriodAndTip(t *testing.T) {
+	cfg := &core.ChainConfig{Opts: map[string]string{}}
+
+	if p := parseMortalPeriod(cfg); p != 0 {
+		t.Fatalf("Got: %d Expected: %d", p, 0)
+	}
+	policy := parseTipPolicy(cfg)
+	if policy.usesFee() || policy.fixed.Sign() != 0 {
+		t.Fatalf("expected zero fixed tip, got: %#v", policy)
+	}
+
+	cfg = &core.ChainConfig{Opts: map[string]string{
+		MortalPeriodOpt:     "64",
+		TipOpt:              "100",
+		TipFeeMultiplierOpt: "0.25",
+	}}
+
+	if p := parseMortalPeriod(cfg); p != 64 {
+		t.Fatalf("Got: %d Expected: %d", p, 64)
+	}
+	policy = parseTipPolicy(cfg)
+	if !policy.usesFee() || policy.fixed.Int64() != 100 {
+		t.Fatalf("unexpected tip policy: %#v", policy)
+	}
+}
+
+func TestParseFetchOptions(t *testing.T) {
+	cfg := &core.ChainConfig{Opts: map[string]string{}}
+
+	if w := parseFetchWorkers(cfg); w != DefaultFetchWorkers {
+		t.Fatalf("Got: %d Expected: %d", w, DefaultFetchWorkers)
+	}
+	if w := parseFetchWindow(cfg); w != DefaultFetchWindow {
+		t.Fatalf("Got: %d Expected: %d", w, DefaultFetchWindow)
+	}
+
+	cfg = &core.ChainConfig{Opts: map[string]string{
+		FetchWorkersOpt: "8",
+		FetchWindowOpt:  "100",
+	}}
+
+	if w := parseFetchWorkers(cfg); w != 8 {
+		t.Fatalf("Got: %d Expected: %d", w, 8)
+	}
+	if w := parseFetchWindow(cfg); w != 100 {
+		t.Fatalf("Got: %d Expected: %d", w, 100)
+	}
+}
+
+func TestParseProxiedAccount(t *testing.T) {
+	if a := parseProxiedAccount(&core.ChainConfig{Opts: map[string]string{}}); a != nil {
+		t.Fatalf("expected no proxied account, got: %x", a)
+	}
+
+	expected := "d43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d"
+	for _, opt := range []string{"5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY", "0x" + expected} {
+		a := parseProxiedAccount(&core.ChainConfig{Opts: map[string]string{ProxiedAccountOpt: opt}})
+		if a == nil || fmt.Sprintf("%x", a[:]) != expected {
+			t.Fatalf("Got: %x Expected: %s", a, expected)
+		}
+	}
+}
+
+func TestParseAckBatchOptions(t *testing.T) {
+	cfg := &core.ChainConfig{Opts: map[string]string{}}
+
+	if w := parseAckBatchWindow(cfg); w != 0 {
+		t.Fatalf("Got: %s Expected: %s", w, time.Duration(0))
+	}
+	if s := parseAckBatchSize(cfg); s != DefaultAckBatchSize {
+		t.Fatalf("Got: %d Expected: %d", s, DefaultAckBatchSize)
+	}
+
+	cfg = &core.ChainConfig{Opts: map[string]string{
+		AckBatchWindowOpt: "1500ms",
+		AckBatchSizeOpt:   "8",
+	}}
+
+	if w := parseAckBatchWindow(cfg); w != 1500*time.Millisecond {
+		t.Fatalf("Got: %s Expected: %s", w, 1500*time.Millisecond)
+	}
+	if s := parseAckBatchSize(cfg); s != 8 {
+		t.Fatalf("Got: %d Expected: %d", s, 8)
+	}
+}
+
+func TestParseResourceCacheOptions(t *testing.T) {
+	cfg := &core.ChainConfig{Opts: map[string]string{}}
+
+	if ttl := parseResourceCacheTTL(cfg); ttl != DefaultResourceCacheTTL {
+		t.Fatalf("Got: %s Expected: %s", ttl, DefaultResourceCacheTTL)
+	}
+	events := parseResourceEvents(cfg, "ChainBridge")
+	if len(events) != 2 || events[0] != "ChainBridge.ResourceSet" || events[1] != "ChainBridge.ResourceRemoved" {
+		t.Fatalf("unexpected resource events: %v", events)
+	}
+
+	cfg = &core.ChainConfig{Opts: map[string]string{
+		ResourceCacheTTLOpt: "0s",
+		ResourceEventsOpt:   "ResourceAdded, ",
+	}}
+
+	if ttl := parseResourceCacheTTL(cfg); ttl != 0 {
+		t.Fatalf("Got: %s Expected: %s", ttl, time.Duration(0))
+	}
+	events = parseResourceEvents(cfg, "Bridge")
+	if len(events) != 1 || events[0] != "Bridge.ResourceAdded" {
+		t.Fatalf("unexpected resource events: %v", events)
+	}
+}
+
+func TestParseUseExtended(t *testing.T) {
+	rId := [32]byte{31: 1}
+	other := [32]byte{31: 2}
+
+	ext := parseUseExtended(&core.ChainConfig{Opts: map[string]string{}})
+	if ext.extends(rId, "Example.transfer") {
+		t.Fatal("calls must not be extended by default")
+	}
+
+	ext = parseUseExtended(&core.ChainConfig{Opts: map[string]string{UseExtendedCallOpt: "true"}})
+	if !ext.extends(rId, "Example.transfer") || !ext.extends(other, "Other.transfer") {
+		t.Fatal("expected all calls to be extended")
+	}
+
+	ext = parseUseExtended(&core.ChainConfig{Opts: map[string]string{
+		UseExtendedCallOpt: fmt.Sprintf("0x%x, Example.remark", rId),
+	}})
+	if !ext.extends(rId, "Other.transfer") {
+		t.Fatal("expected call of resource to be extended")
+	}
+	if !ext.extends(other, "Example.remark") {
+		t.Fatal("expected call of method to be extended")
+	}
+	if ext.extends(other, "Other.transfer") {
+		t.Fatal("unexpected extension of call")
+	}
+}
+
+func TestParseResourceDecimals(t *testing.T) {
+	rId := msg.ResourceId{31: 1}
+
+	table := parseResourceDecimals(&core.ChainConfig{Opts: map[string]string{}})
+	if _, ok := table.Lookup(1, rId); ok {
+		t.Fatal("expected no conversions by default")
+	}
+
+	table = parseResourceDecimals(&core.ChainConfig{Opts: map[string]string{ResourceDecimalsOpt: fmt.Sprintf("0x%x:18:12", rId)}})
+	if conv, ok := table.Lookup(1, rId); !ok || conv.Source != 18 || conv.Destination != 12 {
+		t.Fatalf("unexpected conversion: %v (%t)", conv, ok)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic for invalid table")
+		}
+	}()
+	parseResourceDecimals(&core.ChainConfig{Opts: map[string]string{ResourceDecimalsOpt: "0x01:18"}})
+}
+
+func TestParseDepositPolicies(t *testing.T) {
+	if p := parseDepositPolicies(&core.ChainConfig{Opts: map[string]string{}}); p != nil {
+		t.Fatalf("expected no policies by default, got: %#v", p)
+	}
+
+	path := filepath.Join(t.TempDir(), "policies.json")
+	if err := ioutil.WriteFile(path, []byte(`{"allowedDestinations": [1]}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	p := parseDepositPolicies(&core.ChainConfig{Opts: map[string]string{DepositPoliciesOpt: path}})
+	if err := p.Check(msg.Message{Destination: 2}, nil); err == nil {
+		t.Fatal("expected deposit to be rejected")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic for missing policies")
+		}
+	}()
+	parseDepositPolicies(&core.ChainConfig{Opts: map[string]string{DepositPoliciesOpt: filepath.Join(t.TempDir(), "missing.json")}})
+}
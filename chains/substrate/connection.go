@@ -4,38 +4,69 @@
 package substrate
 
 import (
+	"encoding/json"
 	"fmt"
-	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
-	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
-	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic/extensions"
+	"math/big"
+	"strings"
 	"sync"
 
 	utils "github.com/ChainSafe/ChainBridge/shared/substrate"
 	"github.com/ChainSafe/log15"
 	"github.com/centrifuge/chainbridge-utils/msg"
 	gsrpc "github.com/centrifuge/go-substrate-rpc-client/v4"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/retriever"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/author"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic/extensions"
 )
 
 type Connection struct {
-	api         *gsrpc.SubstrateAPI
-	log         log15.Logger
-	url         string                 // API endpoint
-	name        string                 // Chain name
-	meta        types.Metadata         // Latest chain metadata
-	metaLock    sync.RWMutex           // Lock metadata for updates, allows concurrent reads
-	genesisHash types.Hash             // Chain genesis hash
-	key         *signature.KeyringPair // Keyring used for signing
-	nonce       types.U32              // Latest account nonce
-	nonceLock   sync.Mutex             // Locks nonce for updates
-	stop        <-chan int             // Signals system shutdown, should be observed in all selects and loops
-	sysErr      chan<- error           // Propagates fatal errors to core
+	api             *gsrpc.SubstrateAPI
+	log             log15.Logger
+	url             string                   // API endpoint
+	name            string                   // Chain name
+	meta            types.Metadata           // Latest chain metadata
+	rv              types.RuntimeVersion     // Version of the runtime the latest metadata belongs to
+	metaFrom        uint64                   // First block known to run the latest runtime
+	checkedBlock    uint64                   // Latest block the runtime version was checked at
+	previous        *runtimeMetadata         // Metadata of an older runtime, used to decode historical blocks
+	metaLock        sync.RWMutex             // Lock metadata for updates, allows concurrent reads
+	genesisHash     types.Hash               // Chain genesis hash
+	key             *signature.KeyringPair   // Keyring used for signing
+	nonce           types.U32                // Latest account nonce
+	nonceLock       sync.Mutex               // Locks nonce for updates
+	names           bridgeNames              // Names of the bridge pallet, its storage and events
+	mortalPeriod    uint64                   // Mortality period of extrinsics in blocks, immortal if 0
+	tip             tipPolicy                // Determines the tip of submitted extrinsics
+	waitForFinality bool                     // Wait for extrinsics to be finalized instead of included in a block
+	proxied         *types.AccountID         // Account calls are dispatched for through Proxy.proxy, if set
+	eventRetriever  retriever.EventRetriever // Retrieves events with the metadata that applied at their block
+	stop            <-chan int               // Signals system shutdown, should be observed in all selects and loops
+	sysErr          chan<- error             // Propagates fatal errors to core
+}
+
+// runtimeMetadata is the metadata of a runtime along with the range of blocks known to run it
+type runtimeMetadata struct {
+	meta        types.Metadata
+	specVersion types.U32
+	from, to    uint64
 }
 
 func NewConnection(url string, name string, key *signature.KeyringPair, log log15.Logger, stop <-chan int, sysErr chan<- error) *Connection {
-	return &Connection{url: url, name: name, key: key, log: log, stop: stop, sysErr: sysErr}
+	return &Connection{
+		url:    url,
+		name:   name,
+		key:    key,
+		log:    log,
+		stop:   stop,
+		sysErr: sysErr,
+		names:  defaultBridgeNames(),
+		tip:    tipPolicy{fixed: big.NewInt(0)},
+	}
 }
 
 func (c *Connection) getMetadata() (meta types.Metadata) {
@@ -45,18 +76,177 @@ func (c *Connection) getMetadata() (meta types.Metadata) {
 	return meta
 }
 
+// getVersionedMetadata returns the latest metadata along with the spec version of its runtime
+func (c *Connection) getVersionedMetadata() (meta types.Metadata, specVersion types.U32) {
+	c.metaLock.RLock()
+	meta, specVersion = c.meta, c.rv.SpecVersion
+	c.metaLock.RUnlock()
+	return meta, specVersion
+}
+
+// getRuntime returns the latest metadata along with the version of its runtime, used to sign extrinsics
+func (c *Connection) getRuntime() (meta types.Metadata, rv types.RuntimeVersion) {
+	c.metaLock.RLock()
+	meta, rv = c.meta, c.rv
+	c.metaLock.RUnlock()
+	return meta, rv
+}
+
+// runtimeAt fetches the hash of the block and the version of the runtime that applied at it
+func (c *Connection) runtimeAt(block uint64) (types.Hash, *types.RuntimeVersion, error) {
+	hash, err := c.api.RPC.Chain.GetBlockHash(block)
+	if err != nil {
+		return types.Hash{}, nil, err
+	}
+	rv, err := c.api.RPC.State.GetRuntimeVersion(hash)
+	if err != nil {
+		return types.Hash{}, nil, fmt.Errorf("failed to fetch runtime version of block %d: %w", block, err)
+	}
+	return hash, rv, nil
+}
+
+// lastBlockOf searches (lo, hi) for the last block running the runtime with the given spec version. The runtime
+// must apply at lo and not at hi. Spec versions only increase, so the blocks running a runtime are contiguous.
+func (c *Connection) lastBlockOf(specVersion types.U32, lo, hi uint64) (uint64, error) {
+	for hi-lo > 1 {
+		mid := lo + (hi-lo)/2
+		_, rv, err := c.runtimeAt(mid)
+		if err != nil {
+			return 0, err
+		}
+		if rv.SpecVersion == specVersion {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return lo, nil
+}
+
+// updateMetadata fetches the metadata and runtime version of the latest block
 func (c *Connection) updateMetadata() error {
+	header, err := c.api.RPC.Chain.GetHeaderLatest()
+	if err != nil {
+		return err
+	}
+	block := uint64(header.Number)
+	hash, rv, err := c.runtimeAt(block)
+	if err != nil {
+		return err
+	}
+	meta, err := c.api.RPC.State.GetMetadata(hash)
+	if err != nil {
+		return err
+	}
+
 	c.metaLock.Lock()
-	meta, err := c.api.RPC.State.GetMetadataLatest()
+	c.meta = *meta
+	c.rv = *rv
+	c.metaFrom = block
+	c.checkedBlock = block
+	c.metaLock.Unlock()
+
+	c.log.Debug("Fetched substrate metadata", "specVersion", rv.SpecVersion)
+	return nil
+}
+
+// syncMetadata checks the runtime version at the latest block and updates the metadata if the runtime was
+// upgraded. The metadata of the replaced runtime is kept to decode the blocks produced before the upgrade.
+// It is called by the listener for each finalized head, the writer uses the metadata as of the last check.
+func (c *Connection) syncMetadata(block uint64) error {
+	c.metaLock.RLock()
+	checked, specVersion := c.checkedBlock, c.rv.SpecVersion
+	c.metaLock.RUnlock()
+	if block <= checked {
+		return nil
+	}
+
+	hash, rv, err := c.runtimeAt(block)
 	if err != nil {
-		c.metaLock.Unlock()
 		return err
 	}
+	if rv.SpecVersion == specVersion {
+		c.metaLock.Lock()
+		c.rv = *rv
+		c.checkedBlock = block
+		c.metaLock.Unlock()
+		return nil
+	}
+
+	c.log.Info("Runtime upgrade detected, updating metadata", "from", specVersion, "to", rv.SpecVersion, "block", block)
+	last, err := c.lastBlockOf(specVersion, checked, block)
+	if err != nil {
+		return fmt.Errorf("failed to find runtime upgrade block: %w", err)
+	}
+	meta, err := c.api.RPC.State.GetMetadata(hash)
+	if err != nil {
+		return fmt.Errorf("failed to update metadata: %w", err)
+	}
+
+	c.metaLock.Lock()
+	c.previous = &runtimeMetadata{meta: c.meta, specVersion: specVersion, from: c.metaFrom, to: last}
 	c.meta = *meta
+	c.rv = *rv
+	c.metaFrom = block
+	c.checkedBlock = block
 	c.metaLock.Unlock()
 	return nil
 }
 
+// metadataAt returns the metadata of the runtime that applied at the given block, along with its spec version.
+// Blocks outside the ranges known to run the latest or previous runtime are resolved with the node.
+func (c *Connection) metadataAt(block uint64) (*types.Metadata, types.U32, error) {
+	c.metaLock.RLock()
+	if block >= c.metaFrom {
+		meta, specVersion := c.meta, c.rv.SpecVersion
+		c.metaLock.RUnlock()
+		return &meta, specVersion, nil
+	}
+	if p := c.previous; p != nil && block >= p.from && block <= p.to {
+		c.metaLock.RUnlock()
+		return &p.meta, p.specVersion, nil
+	}
+	c.metaLock.RUnlock()
+
+	return c.resolveMetadataAt(block)
+}
+
+// resolveMetadataAt fetches the runtime version of a block older than the known runtimes. If it is the latest
+// runtime its range is extended, otherwise the range of the runtime is searched and it replaces the previous one.
+func (c *Connection) resolveMetadataAt(block uint64) (*types.Metadata, types.U32, error) {
+	hash, rv, err := c.runtimeAt(block)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	c.metaLock.Lock()
+	if rv.SpecVersion == c.rv.SpecVersion {
+		if block < c.metaFrom {
+			c.metaFrom = block
+		}
+		meta := c.meta
+		c.metaLock.Unlock()
+		return &meta, rv.SpecVersion, nil
+	}
+	metaFrom := c.metaFrom
+	c.metaLock.Unlock()
+
+	last, err := c.lastBlockOf(rv.SpecVersion, block, metaFrom)
+	if err != nil {
+		return nil, 0, err
+	}
+	meta, err := c.api.RPC.State.GetMetadata(hash)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch metadata of block %d: %w", block, err)
+	}
+	c.log.Debug("Fetched substrate metadata of historical runtime", "specVersion", rv.SpecVersion, "from", block, "to", last)
+
+	c.metaLock.Lock()
+	c.previous = &runtimeMetadata{meta: *meta, specVersion: rv.SpecVersion, from: block, to: last}
+	c.metaLock.Unlock()
+	return meta, rv.SpecVersion, nil
+}
+
 func (c *Connection) Connect() error {
 	c.log.Info("Connecting to substrate chain...", "url", c.url)
 	api, err := gsrpc.NewSubstrateAPI(c.url)
@@ -66,12 +256,10 @@ func (c *Connection) Connect() error {
 	c.api = api
 
 	// Fetch metadata
-	meta, err := api.RPC.State.GetMetadataLatest()
+	err = c.updateMetadata()
 	if err != nil {
 		return err
 	}
-	c.meta = *meta
-	c.log.Debug("Fetched substrate metadata")
 
 	// Fetch genesis hash
 	genesisHash, err := c.api.RPC.Chain.GetBlockHash(0)
@@ -80,6 +268,12 @@ func (c *Connection) Connect() error {
 	}
 	c.genesisHash = genesisHash
 	c.log.Debug("Fetched substrate genesis hash", "hash", genesisHash.Hex())
+
+	eventRetriever, err := newEventRetriever(c)
+	if err != nil {
+		return err
+	}
+	c.eventRetriever = eventRetriever
 	return nil
 }
 
@@ -88,9 +282,10 @@ func (c *Connection) Connect() error {
 func (c *Connection) SubmitTx(method utils.Method, args ...interface{}) error {
 	c.log.Debug("Submitting substrate call...", "method", method, "sender", c.key.Address)
 
-	meta := c.getMetadata()
+	// The metadata must match the runtime version for signing
+	meta, rv := c.getRuntime()
 
-	// Create call and extrinsic
+	// Create call
 	call, err := types.NewCall(
 		&meta,
 		string(method),
@@ -99,40 +294,76 @@ func (c *Connection) SubmitTx(method utils.Method, args ...interface{}) error {
 	if err != nil {
 		return fmt.Errorf("failed to construct call: %w", err)
 	}
-	ext := extrinsic.NewDynamicExtrinsic(&call)
 
-	// Get latest runtime version
-	rv, err := c.api.RPC.State.GetRuntimeVersionLatest()
+	_, err = c.submitCall(call, &meta, &rv)
+	return err
+}
+
+// submitCall signs and submits an extrinsic for the call, wrapping it in Proxy.proxy if a proxied account is set.
+// The metadata and runtime version must be those the call was constructed with. Once the extrinsic is included, the
+// events it emitted are returned.
+func (c *Connection) submitCall(call types.Call, meta *types.Metadata, rv *types.RuntimeVersion) ([]*parser.Event, error) {
+	var err error
+	if c.proxied != nil {
+		call, err = proxyCall(meta, *c.proxied, call)
+		if err != nil {
+			return nil, fmt.Errorf("failed to construct proxy call: %w", err)
+		}
+	}
+
+	era, eraHash, err := c.getEra()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	c.nonceLock.Lock()
 	latestNonce, err := c.getLatestNonce()
 	if err != nil {
 		c.nonceLock.Unlock()
-		return err
+		return nil, err
 	}
 	if latestNonce > c.nonce {
 		c.nonce = latestNonce
 	}
 
-	// Sign the extrinsic
-	err = ext.Sign(
-		*c.key,
-		&meta,
-		extrinsic.WithEra(types.ExtrinsicEra{IsImmortalEra: true}, c.genesisHash),
-		extrinsic.WithNonce(types.NewUCompactFromUInt(uint64(c.nonce))),
-		extrinsic.WithTip(types.NewUCompactFromUInt(0)),
-		extrinsic.WithSpecVersion(rv.SpecVersion),
-		extrinsic.WithTransactionVersion(rv.TransactionVersion),
-		extrinsic.WithGenesisHash(c.genesisHash),
-		extrinsic.WithMetadataMode(extensions.CheckMetadataModeDisabled, extensions.CheckMetadataHash{Hash: types.NewEmptyOption[types.H256]()}),
-	)
+	sign := func(tip *big.Int) (extrinsic.DynamicExtrinsic, error) {
+		ext := extrinsic.NewDynamicExtrinsic(&call)
+		err := ext.Sign(
+			*c.key,
+			meta,
+			extrinsic.WithEra(era, eraHash),
+			extrinsic.WithNonce(types.NewUCompactFromUInt(uint64(c.nonce))),
+			extrinsic.WithTip(types.NewUCompact(tip)),
+			extrinsic.WithSpecVersion(rv.SpecVersion),
+			extrinsic.WithTransactionVersion(rv.TransactionVersion),
+			extrinsic.WithGenesisHash(c.genesisHash),
+			extrinsic.WithMetadataMode(extensions.CheckMetadataModeDisabled, extensions.CheckMetadataHash{Hash: types.NewEmptyOption[types.H256]()}),
+		)
+		return ext, err
+	}
 
+	// Sign the extrinsic
+	ext, err := sign(c.tip.tip(nil))
 	if err != nil {
 		c.nonceLock.Unlock()
-		return err
+		return nil, err
+	}
+
+	// Re-sign with a tip derived from the fee of the signed extrinsic
+	if c.tip.usesFee() {
+		fee, err := c.queryPartialFee(ext)
+		if err != nil {
+			c.nonceLock.Unlock()
+			return nil, err
+		}
+		tip := c.tip.tip(fee)
+		c.log.Trace("Calculated extrinsic tip", "partialFee", fee, "tip", tip)
+
+		ext, err = sign(tip)
+		if err != nil {
+			c.nonceLock.Unlock()
+			return nil, err
+		}
 	}
 
 	// Submit and watch the extrinsic
@@ -140,38 +371,160 @@ func (c *Connection) SubmitTx(method utils.Method, args ...interface{}) error {
 	c.nonce++
 	c.nonceLock.Unlock()
 	if err != nil {
-		return fmt.Errorf("submission of extrinsic failed: %w", err)
+		return nil, fmt.Errorf("submission of extrinsic failed: %w", err)
 	}
 	c.log.Trace("Extrinsic submission succeeded")
 	defer sub.Unsubscribe()
 
-	return c.watchSubmission(sub)
+	return c.watchSubmission(sub, ext)
+}
+
+// getEra returns the era extrinsics are signed with, along with the hash of the block the era starts at
+func (c *Connection) getEra() (types.ExtrinsicEra, types.Hash, error) {
+	if c.mortalPeriod == 0 {
+		return types.ExtrinsicEra{IsImmortalEra: true}, c.genesisHash, nil
+	}
+
+	header, err := c.api.RPC.Chain.GetHeaderLatest()
+	if err != nil {
+		return types.ExtrinsicEra{}, types.Hash{}, err
+	}
+	era, birth := mortalEra(uint64(header.Number), c.mortalPeriod)
+	hash, err := c.api.RPC.Chain.GetBlockHash(birth)
+	if err != nil {
+		return types.ExtrinsicEra{}, types.Hash{}, err
+	}
+	return era, hash, nil
+}
+
+// paymentInfo is the result of payment_queryInfo, only the fields used by the relayer are decoded
+type paymentInfo struct {
+	PartialFee json.Number `json:"partialFee"`
+}
+
+// queryPartialFee returns the partial fee of a signed extrinsic, as reported by payment_queryInfo
+func (c *Connection) queryPartialFee(ext extrinsic.DynamicExtrinsic) (*big.Int, error) {
+	enc, err := codec.EncodeToHex(ext)
+	if err != nil {
+		return nil, err
+	}
+
+	var info paymentInfo
+	err = c.api.Client.Call(&info, "payment_queryInfo", enc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query payment info: %w", err)
+	}
+
+	fee, ok := new(big.Int).SetString(info.PartialFee.String(), 10)
+	if !ok {
+		return nil, fmt.Errorf("unable to parse partial fee %q", info.PartialFee)
+	}
+	return fee, nil
 }
 
-func (c *Connection) watchSubmission(sub *author.ExtrinsicStatusSubscription) error {
+// watchSubmission waits for the extrinsic to be included in a block, or finalized if waitForFinality is set.
+// The events of the block are then checked for the result of the extrinsic.
+func (c *Connection) watchSubmission(sub *author.ExtrinsicStatusSubscription, ext extrinsic.DynamicExtrinsic) ([]*parser.Event, error) {
 	for {
 		select {
 		case <-c.stop:
-			return TerminatedError
+			return nil, TerminatedError
 		case status := <-sub.Chan():
 			switch {
 			case status.IsInBlock:
 				c.log.Trace("Extrinsic included in block", "block", status.AsInBlock.Hex())
-				return nil
+				if c.waitForFinality {
+					continue
+				}
+				return c.checkExtrinsicResult(status.AsInBlock, ext)
+			case status.IsFinalized:
+				c.log.Trace("Extrinsic finalized", "block", status.AsFinalized.Hex())
+				return c.checkExtrinsicResult(status.AsFinalized, ext)
 			case status.IsRetracted:
-				return fmt.Errorf("extrinsic retracted: %s", status.AsRetracted.Hex())
+				if c.waitForFinality {
+					c.log.Trace("Extrinsic block retracted, waiting for finality", "block", status.AsRetracted.Hex())
+					continue
+				}
+				return nil, fmt.Errorf("extrinsic retracted: %s", status.AsRetracted.Hex())
+			case status.IsFinalityTimeout:
+				return nil, fmt.Errorf("extrinsic finality timeout: %s", status.AsFinalityTimeout.Hex())
+			case status.IsUsurped:
+				return nil, fmt.Errorf("extrinsic usurped: %s", status.AsUsurped.Hex())
 			case status.IsDropped:
-				return fmt.Errorf("extrinsic dropped from network")
+				return nil, fmt.Errorf("extrinsic dropped from network")
 			case status.IsInvalid:
-				return fmt.Errorf("extrinsic invalid")
+				return nil, fmt.Errorf("extrinsic invalid")
 			}
 		case err := <-sub.Err():
 			c.log.Trace("Extrinsic subscription error", "err", err)
-			return err
+			return nil, err
 		}
 	}
 }
 
+// rawBlock is the result of chain_getBlock with the extrinsics left encoded
+type rawBlock struct {
+	Block struct {
+		Extrinsics []string `json:"extrinsics"`
+	} `json:"block"`
+}
+
+// checkExtrinsicResult looks up the index of the extrinsic in the block and returns an ExtrinsicFailedError
+// if the block's events report that its dispatch failed. Otherwise the events emitted by the extrinsic are returned.
+func (c *Connection) checkExtrinsicResult(blockHash types.Hash, ext extrinsic.DynamicExtrinsic) ([]*parser.Event, error) {
+	enc, err := codec.EncodeToHex(ext)
+	if err != nil {
+		return nil, err
+	}
+
+	var block rawBlock
+	err = c.api.Client.Call(&block, "chain_getBlock", blockHash.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch block %s: %w", blockHash.Hex(), err)
+	}
+
+	index := -1
+	for i, e := range block.Block.Extrinsics {
+		if strings.EqualFold(e, enc) {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return nil, fmt.Errorf("extrinsic not found in block %s", blockHash.Hex())
+	}
+
+	events, err := c.eventRetriever.GetEvents(blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	var emitted []*parser.Event
+	for _, event := range events {
+		if event.Phase == nil || !event.Phase.IsApplyExtrinsic || int(event.Phase.AsApplyExtrinsic) != index {
+			continue
+		}
+		emitted = append(emitted, event)
+		switch event.Name {
+		case ExtrinsicSuccessEvent:
+			return emitted, nil
+		case ExtrinsicFailedEvent:
+			meta := c.getMetadata()
+			if dispatchErr, ok := findDispatchError(event.Fields); ok {
+				return nil, newExtrinsicFailedError(&meta, dispatchErr)
+			}
+			return nil, &ExtrinsicFailedError{Name: "Unknown"}
+		case ProxyExecutedEvent:
+			// The proxy call succeeds even if the proxied call failed, its result is reported by this event
+			if dispatchErr, ok := findDispatchError(event.Fields); ok {
+				meta := c.getMetadata()
+				return nil, newExtrinsicFailedError(&meta, dispatchErr)
+			}
+		}
+	}
+	return nil, fmt.Errorf("no result event found for extrinsic %d in block %s", index, blockHash.Hex())
+}
+
 // queryStorage performs a storage lookup. Arguments may be nil, result must be a pointer.
 func (c *Connection) queryStorage(prefix, method string, arg1, arg2 []byte, result interface{}) (bool, error) {
 	// Fetch account nonce
@@ -197,14 +550,46 @@ func getConst(meta *types.Metadata, prefix, name string, res interface{}) error
 	return fmt.Errorf("could not find constant %s.%s", prefix, name)
 }
 
+// findTypeLookupIndex walks the portable type registry of the metadata and returns the ID of the type with the given path
+func findTypeLookupIndex(meta *types.Metadata, path []string) (int64, error) {
+	for _, typ := range meta.AsMetadataV14.Lookup.Types {
+		if len(typ.Type.Path) != len(path) {
+			continue
+		}
+		match := true
+		for i, segment := range typ.Type.Path {
+			if string(segment) != path[i] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return typ.ID.Int64(), nil
+		}
+	}
+	return 0, fmt.Errorf("could not find type %s in metadata", strings.Join(path, "::"))
+}
+
 func (c *Connection) getConst(prefix, name string, res interface{}) error {
 	meta := c.getMetadata()
 	return getConst(&meta, prefix, name, res)
 }
 
+// ss58Prefix returns the SS58 network prefix of the chain, as set by the System.SS58Prefix constant of the runtime.
+// Returns nil if the runtime does not define it.
+func (c *Connection) ss58Prefix() *uint16 {
+	var prefix types.U16
+	if err := c.getConst("System", "SS58Prefix", &prefix); err != nil {
+		c.log.Warn("Unable to determine SS58 network prefix, SS58 recipients of any network are accepted", "err", err)
+		return nil
+	}
+	network := uint16(prefix)
+	return &network
+}
+
 func (c *Connection) checkChainId(expected msg.ChainId) error {
 	var actual msg.ChainId
-	err := c.getConst(utils.BridgePalletName, "ChainId", &actual)
+	err := c.getConst(c.names.pallet, "ChainId", &actual)
 	if err != nil {
 		return err
 	}
@@ -216,6 +601,19 @@ func (c *Connection) checkChainId(expected msg.ChainId) error {
 	return nil
 }
 
+// accountID returns the account the relayer votes with. This is the proxied account if one is set,
+// otherwise the account of the signing key.
+func (c *Connection) accountID() (types.AccountID, error) {
+	if c.proxied != nil {
+		return *c.proxied, nil
+	}
+	accountID, err := types.NewAccountID(c.key.PublicKey)
+	if err != nil {
+		return types.AccountID{}, err
+	}
+	return *accountID, nil
+}
+
 func (c *Connection) getLatestNonce() (types.U32, error) {
 	var acct types.AccountInfo
 	exists, err := c.queryStorage("System", "Account", c.key.PublicKey, nil, &acct)
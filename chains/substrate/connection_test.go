@@ -4,6 +4,7 @@
 package substrate
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
@@ -87,3 +88,37 @@ func TestConnect_SubmitTx(t *testing.T) {
 		return
 	}
 }
+
+func TestFindTypeLookupIndex(t *testing.T) {
+	newType := func(id int64, path ...string) types.PortableTypeV14 {
+		var p types.Si1Path
+		for _, segment := range path {
+			p = append(p, types.Text(segment))
+		}
+		return types.PortableTypeV14{
+			ID:   types.NewSi1LookupTypeID(big.NewInt(id)),
+			Type: types.Si1Type{Path: p},
+		}
+	}
+
+	var meta types.Metadata
+	meta.AsMetadataV14.Lookup.Types = []types.PortableTypeV14{
+		newType(0, "primitive_types", "H256"),
+		newType(1, "primitive_types"),
+		newType(42, "primitive_types", "U256"),
+	}
+
+	index, err := findTypeLookupIndex(&meta, U256TypePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if index != 42 {
+		t.Fatalf("Got: %d Expected: %d", index, 42)
+	}
+
+	meta.AsMetadataV14.Lookup.Types = meta.AsMetadataV14.Lookup.Types[:2]
+	_, err = findTypeLookupIndex(&meta, U256TypePath)
+	if err == nil {
+		t.Fatal("expected error for missing type")
+	}
+}
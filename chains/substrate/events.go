@@ -6,6 +6,8 @@ package substrate
 import (
 	"errors"
 	"fmt"
+
+	"github.com/ChainSafe/ChainBridge/chains/payload"
 	"github.com/ChainSafe/log15"
 	"github.com/centrifuge/chainbridge-utils/msg"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
@@ -13,8 +15,9 @@ import (
 )
 
 type eventName string
-type eventHandler func(registry.DecodedFields, log15.Logger) (msg.Message, error)
+type eventHandler func(registry.DecodedFields, bridgeNames, log15.Logger) (msg.Message, error)
 
+// Default event names, also used to key the handlers of the listener
 const FungibleTransfer eventName = "ChainBridge.FungibleTransfer"
 const NonFungibleTransfer eventName = "ChainBridge.NonFungibleTransfer"
 const GenericTransfer eventName = "ChainBridge.GenericTransfer"
@@ -28,7 +31,7 @@ var Subscriptions = []struct {
 	{GenericTransfer, genericTransferHandler},
 }
 
-func fungibleTransferHandler(eventFields registry.DecodedFields, log log15.Logger) (msg.Message, error) {
+func fungibleTransferHandler(eventFields registry.DecodedFields, names bridgeNames, log log15.Logger) (msg.Message, error) {
 	chainID, err := getFieldValueAsType[types.U8]("ChainId", eventFields)
 	if err != nil {
 		return msg.Message{}, err
@@ -49,35 +52,37 @@ func fungibleTransferHandler(eventFields registry.DecodedFields, log log15.Logge
 		return msg.Message{}, err
 	}
 
-	amount, err := getFieldValueAsType[types.U256]("primitive_types.U256.U256", eventFields)
+	amount, err := getFieldValueAsType[types.U256](names.amountField, eventFields)
 	if err != nil {
 		return msg.Message{}, err
 	}
 
-	recipient, err := getFieldValueAsByteSlice("Vec<u8>", eventFields)
+	recipient, err := getFieldValueAsByteSlice(names.bytesField, eventFields)
 	if err != nil {
 		return msg.Message{}, err
 	}
 
 	log.Info("Got fungible transfer event!", "destination", recipient, "resourceId", fmt.Sprintf("%x", resourceID), "amount", amount)
 
-	return msg.NewFungibleTransfer(
+	p := &payload.Fungible{Amount: amount.Int, Recipient: recipient}
+	if err := p.Validate(); err != nil {
+		return msg.Message{}, err
+	}
+	return p.Message(
 		0, // Unset
 		msg.ChainId(chainID),
 		msg.Nonce(depositNonce),
-		amount.Int,
 		resourceID,
-		recipient,
 	), nil
 }
 
-func nonFungibleTransferHandler(_ registry.DecodedFields, log log15.Logger) (msg.Message, error) {
+func nonFungibleTransferHandler(_ registry.DecodedFields, _ bridgeNames, log log15.Logger) (msg.Message, error) {
 	log.Warn("Got non-fungible transfer event!")
 
 	return msg.Message{}, errors.New("non-fungible transfer not supported")
 }
 
-func genericTransferHandler(eventFields registry.DecodedFields, log log15.Logger) (msg.Message, error) {
+func genericTransferHandler(eventFields registry.DecodedFields, names bridgeNames, log log15.Logger) (msg.Message, error) {
 	chainID, err := getFieldValueAsType[types.U8]("ChainId", eventFields)
 	if err != nil {
 		return msg.Message{}, err
@@ -98,19 +103,22 @@ func genericTransferHandler(eventFields registry.DecodedFields, log log15.Logger
 		return msg.Message{}, err
 	}
 
-	metadata, err := getFieldValueAsByteSlice("Vec<u8>", eventFields)
+	metadata, err := getFieldValueAsByteSlice(names.bytesField, eventFields)
 	if err != nil {
 		return msg.Message{}, err
 	}
 
 	log.Info("Got generic transfer event!", "destination", chainID, "resourceId", fmt.Sprintf("%x", resourceID))
 
-	return msg.NewGenericTransfer(
+	p := &payload.Generic{Metadata: metadata}
+	if err := p.Validate(); err != nil {
+		return msg.Message{}, err
+	}
+	return p.Message(
 		0, // Unset
 		msg.ChainId(chainID),
 		msg.Nonce(depositNonce),
 		resourceID,
-		metadata,
 	), nil
 }
 
@@ -0,0 +1,145 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package substrate
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// Bounds of the mortality period, as enforced by the runtime
+const (
+	MinMortalPeriod = 4
+	MaxMortalPeriod = 1 << 16
+)
+
+// mortalEra encodes an era starting at the given block and lasting for period blocks. The period is rounded up
+// to the next power of two and clamped to [MinMortalPeriod, MaxMortalPeriod]. The block the era starts at
+// (its birth) is returned alongside, as its hash is part of the signed payload.
+func mortalEra(current, period uint64) (types.ExtrinsicEra, uint64) {
+	if period < MinMortalPeriod {
+		period = MinMortalPeriod
+	}
+	if period > MaxMortalPeriod {
+		period = MaxMortalPeriod
+	}
+	// Round up to the next power of two
+	period = 1 << bits.Len64(period-1)
+
+	phase := current % period
+	quantizeFactor := period >> 12
+	if quantizeFactor < 1 {
+		quantizeFactor = 1
+	}
+	quantizedPhase := phase / quantizeFactor * quantizeFactor
+
+	low := uint64(bits.TrailingZeros64(period) - 1)
+	if low < 1 {
+		low = 1
+	}
+	if low > 15 {
+		low = 15
+	}
+	encoded := low | (quantizedPhase/quantizeFactor)<<4
+
+	birth := (current-quantizedPhase)/period*period + quantizedPhase
+
+	return types.ExtrinsicEra{
+		IsMortalEra: true,
+		AsMortalEra: types.MortalEra{First: byte(encoded), Second: byte(encoded >> 8)},
+	}, birth
+}
+
+// tipPolicy determines the tip attached to submitted extrinsics
+type tipPolicy struct {
+	fixed         *big.Int   // Tip used when no fee multiplier is set, otherwise the minimum tip
+	feeMultiplier *big.Float // If set, the tip is derived from the partial fee of the extrinsic
+}
+
+// usesFee returns true if the tip is derived from the fee reported by payment_queryInfo
+func (p tipPolicy) usesFee() bool {
+	return p.feeMultiplier != nil
+}
+
+// tip calculates the tip for an extrinsic with the given partial fee
+func (p tipPolicy) tip(partialFee *big.Int) *big.Int {
+	if !p.usesFee() || partialFee == nil {
+		return new(big.Int).Set(p.fixed)
+	}
+	tip, _ := new(big.Float).Mul(new(big.Float).SetInt(partialFee), p.feeMultiplier).Int(nil)
+	if tip.Cmp(p.fixed) < 0 {
+		return new(big.Int).Set(p.fixed)
+	}
+	return tip
+}
+
+// Events emitted by the System pallet to report the result of an extrinsic
+const (
+	ExtrinsicSuccessEvent = "System.ExtrinsicSuccess"
+	ExtrinsicFailedEvent  = "System.ExtrinsicFailed"
+)
+
+// DispatchErrorTypePath is the path of the DispatchError type in the type registry of the runtime
+var DispatchErrorTypePath = []string{"sp_runtime", "DispatchError"}
+
+// ExtrinsicFailedError is returned if an extrinsic was included in a block, but failed to dispatch
+type ExtrinsicFailedError struct {
+	Module string // Name of the pallet that returned the error, empty for non-module errors
+	Name   string // Name of the error
+}
+
+func (e *ExtrinsicFailedError) Error() string {
+	if e.Module != "" {
+		return fmt.Sprintf("extrinsic failed: %s.%s", e.Module, e.Name)
+	}
+	return fmt.Sprintf("extrinsic failed: %s", e.Name)
+}
+
+// Retriable returns true if resubmitting the extrinsic may succeed. Module errors, bad origins and failed
+// lookups are deterministic and will fail again.
+func (e *ExtrinsicFailedError) Retriable() bool {
+	return e.Module == "" && e.Name != "BadOrigin" && e.Name != "CannotLookup"
+}
+
+// newExtrinsicFailedError resolves the module and error name of a dispatch error from the metadata
+func newExtrinsicFailedError(meta *types.Metadata, dispatchErr types.DispatchError) *ExtrinsicFailedError {
+	switch {
+	case dispatchErr.IsModule:
+		moduleErr := dispatchErr.ModuleError
+		res := &ExtrinsicFailedError{
+			Module: fmt.Sprintf("Pallet%d", moduleErr.Index),
+			Name:   fmt.Sprintf("Error%d", moduleErr.Error[0]),
+		}
+		for _, pallet := range meta.AsMetadataV14.Pallets {
+			if pallet.Index == moduleErr.Index {
+				res.Module = string(pallet.Name)
+			}
+		}
+		if metaErr, err := meta.FindError(moduleErr.Index, moduleErr.Error); err == nil {
+			res.Name = metaErr.Name
+		}
+		return res
+	case dispatchErr.IsCannotLookup:
+		return &ExtrinsicFailedError{Name: "CannotLookup"}
+	case dispatchErr.IsBadOrigin:
+		return &ExtrinsicFailedError{Name: "BadOrigin"}
+	case dispatchErr.IsConsumerRemaining:
+		return &ExtrinsicFailedError{Name: "ConsumerRemaining"}
+	case dispatchErr.IsNoProviders:
+		return &ExtrinsicFailedError{Name: "NoProviders"}
+	case dispatchErr.IsTooManyConsumers:
+		return &ExtrinsicFailedError{Name: "TooManyConsumers"}
+	case dispatchErr.IsToken:
+		return &ExtrinsicFailedError{Name: "Token"}
+	case dispatchErr.IsArithmetic:
+		return &ExtrinsicFailedError{Name: "Arithmetic"}
+	case dispatchErr.IsTransactional:
+		return &ExtrinsicFailedError{Name: "Transactional"}
+	default:
+		return &ExtrinsicFailedError{Name: "Other"}
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package substrate
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+func TestMortalEra(t *testing.T) {
+	testCases := []struct {
+		current  uint64
+		period   uint64
+		expected types.MortalEra
+		birth    uint64
+	}{
+		// Vectors taken from the substrate era tests
+		{current: 42, period: 64, expected: types.MortalEra{First: 0xa5, Second: 0x02}, birth: 42},
+		{current: 20000, period: 32768, expected: types.MortalEra{First: 0x4e, Second: 0x9c}, birth: 20000},
+		// Period is rounded up to a power of two
+		{current: 42, period: 50, expected: types.MortalEra{First: 0xa5, Second: 0x02}, birth: 42},
+		// Phase is relative to the period
+		{current: 1000, period: 64, expected: types.MortalEra{First: 0x85, Second: 0x02}, birth: 1000},
+	}
+
+	for _, tc := range testCases {
+		era, birth := mortalEra(tc.current, tc.period)
+		if !era.IsMortalEra {
+			t.Fatalf("expected mortal era for block %d", tc.current)
+		}
+		if era.AsMortalEra != tc.expected {
+			t.Fatalf("Got: %#v Expected: %#v", era.AsMortalEra, tc.expected)
+		}
+		if birth != tc.birth {
+			t.Fatalf("Got: %d Expected: %d", birth, tc.birth)
+		}
+	}
+}
+
+func TestTipPolicy(t *testing.T) {
+	fixed := tipPolicy{fixed: big.NewInt(10)}
+	if fixed.tip(big.NewInt(1000)).Cmp(big.NewInt(10)) != 0 {
+		t.Fatalf("Got: %s Expected: %d", fixed.tip(big.NewInt(1000)), 10)
+	}
+
+	derived := tipPolicy{fixed: big.NewInt(10), feeMultiplier: big.NewFloat(0.5)}
+	if derived.tip(big.NewInt(1000)).Cmp(big.NewInt(500)) != 0 {
+		t.Fatalf("Got: %s Expected: %d", derived.tip(big.NewInt(1000)), 500)
+	}
+	// The fixed tip is the minimum
+	if derived.tip(big.NewInt(4)).Cmp(big.NewInt(10)) != 0 {
+		t.Fatalf("Got: %s Expected: %d", derived.tip(big.NewInt(4)), 10)
+	}
+}
+
+func TestExtrinsicFailedError(t *testing.T) {
+	var meta types.Metadata
+	meta.AsMetadataV14.Pallets = []types.PalletMetadataV14{{Name: "ChainBridge", Index: 9}}
+
+	moduleErr := newExtrinsicFailedError(&meta, types.DispatchError{
+		IsModule:    true,
+		ModuleError: types.ModuleError{Index: 9, Error: [4]types.U8{3}},
+	})
+	if moduleErr.Module != "ChainBridge" {
+		t.Fatalf("Got: %s Expected: %s", moduleErr.Module, "ChainBridge")
+	}
+	if moduleErr.Retriable() {
+		t.Fatal("module errors should not be retried")
+	}
+
+	if newExtrinsicFailedError(&meta, types.DispatchError{IsBadOrigin: true}).Retriable() {
+		t.Fatal("bad origin should not be retried")
+	}
+
+	transactional := newExtrinsicFailedError(&meta, types.DispatchError{IsTransactional: true})
+	if !transactional.Retriable() {
+		t.Fatal("transactional errors should be retried")
+	}
+	if transactional.Error() != "extrinsic failed: Transactional" {
+		t.Fatalf("Got: %s Expected: %s", transactional.Error(), "extrinsic failed: Transactional")
+	}
+}
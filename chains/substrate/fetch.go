@@ -0,0 +1,94 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package substrate
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// Defaults for fetching blocks in parallel
+const (
+	DefaultFetchWorkers = 4
+	DefaultFetchWindow  = 32
+)
+
+// blockEventRetriever is implemented by event retrievers that select the metadata by block number
+type blockEventRetriever interface {
+	GetBlockEvents(block uint64, blockHash types.Hash) ([]*parser.Event, error)
+}
+
+// blockEvents holds the fetched events of a block, or the error that occurred while fetching them
+type blockEvents struct {
+	number uint64
+	hash   types.Hash
+	events []*parser.Event
+	err    error
+}
+
+// fetchBlocks fetches the hashes and events of the blocks in [start, end] using a bounded pool of workers.
+// Results are returned in block order. Blocks after the first failed block are dropped from the result.
+func (l *listener) fetchBlocks(start, end uint64) []blockEvents {
+	results := make([]blockEvents, end-start+1)
+
+	workers := l.fetchWorkers
+	if workers < 1 {
+		workers = 1
+	}
+	if uint64(workers) > end-start+1 {
+		workers = int(end - start + 1)
+	}
+
+	blocks := make(chan uint64)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for block := range blocks {
+				results[block-start] = l.fetchBlock(block)
+			}
+		}()
+	}
+
+	for block := start; block <= end; block++ {
+		blocks <- block
+	}
+	close(blocks)
+	wg.Wait()
+
+	for i, res := range results {
+		if res.err != nil {
+			return results[:i+1]
+		}
+	}
+	return results
+}
+
+// fetchBlock fetches the hash and events of a block
+func (l *listener) fetchBlock(block uint64) blockEvents {
+	res := blockEvents{number: block}
+
+	res.hash, res.err = l.conn.api.RPC.Chain.GetBlockHash(block)
+	if res.err != nil {
+		return res
+	}
+
+	l.log.Trace("Fetching events for block", "hash", res.hash.Hex())
+	var events []*parser.Event
+	var err error
+	if r, ok := l.eventRetriever.(blockEventRetriever); ok {
+		events, err = r.GetBlockEvents(block, res.hash)
+	} else {
+		events, err = l.eventRetriever.GetEvents(res.hash)
+	}
+	if err != nil {
+		res.err = fmt.Errorf("event retrieving error: %w", err)
+	}
+	res.events = events
+	return res
+}
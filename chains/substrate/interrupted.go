@@ -0,0 +1,140 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package substrate
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/ChainSafe/ChainBridge/chains/store"
+	"github.com/centrifuge/chainbridge-utils/msg"
+)
+
+const InterruptedPathPostfix = ".chainbridge/interrupted"
+
+// interruptedStore persists the messages the writer was resolving when the relayer shut down, so they are resolved
+// again after a restart
+type interruptedStore struct {
+	path     string // Path excluding filename
+	fullPath string
+	lock     sync.Mutex
+}
+
+func newInterruptedStore(path string, chain msg.ChainId, relayer string) (*interruptedStore, error) {
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		path = filepath.Join(home, InterruptedPathPostfix)
+	}
+
+	return &interruptedStore{
+		path:     path,
+		fullPath: filepath.Join(path, fmt.Sprintf("%s-%d.interrupted", relayer, chain)),
+	}, nil
+}
+
+// add records the message, unless it is already recorded
+func (s *interruptedStore) add(m msg.Message) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	stored, err := s.load()
+	if err != nil {
+		return err
+	}
+	for _, sm := range stored {
+		if sm.Source == m.Source && sm.DepositNonce == m.DepositNonce {
+			return nil
+		}
+	}
+	encoded, err := store.EncodeMessage(m)
+	if err != nil {
+		return err
+	}
+	return s.write(append(stored, encoded))
+}
+
+// pending returns the recorded messages. They stay recorded until removed, so they are not lost if the relayer shuts
+// down again before they are resolved.
+func (s *interruptedStore) pending() ([]msg.Message, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	stored, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	msgs := make([]msg.Message, 0, len(stored))
+	for _, sm := range stored {
+		m, err := sm.Decode()
+		if err != nil {
+			return nil, fmt.Errorf("invalid message in %s: %w", s.fullPath, err)
+		}
+		msgs = append(msgs, m)
+	}
+	return msgs, nil
+}
+
+// remove deletes the record of the message
+func (s *interruptedStore) remove(m msg.Message) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	stored, err := s.load()
+	if err != nil {
+		return err
+	}
+	rest := make([]store.Message, 0, len(stored))
+	for _, sm := range stored {
+		if sm.Source != m.Source || sm.DepositNonce != m.DepositNonce {
+			rest = append(rest, sm)
+		}
+	}
+	if len(rest) == len(stored) {
+		return nil
+	} else if len(rest) == 0 {
+		if err = os.Remove(s.fullPath); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	return s.write(rest)
+}
+
+func (s *interruptedStore) load() ([]store.Message, error) {
+	data, err := ioutil.ReadFile(s.fullPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var stored []store.Message
+	if err = json.Unmarshal(data, &stored); err != nil {
+		return nil, fmt.Errorf("invalid interrupted messages file %s: %w", s.fullPath, err)
+	}
+	return stored, nil
+}
+
+func (s *interruptedStore) write(stored []store.Message) error {
+	data, err := json.Marshal(stored)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(s.path, os.ModePerm); err != nil {
+		return err
+	}
+	// Write to a temporary file first, so a crash cannot leave a partial file
+	tmp := s.fullPath + ".tmp"
+	if err = ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.fullPath)
+}
@@ -0,0 +1,58 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package substrate
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/chainbridge-utils/msg"
+)
+
+func TestInterruptedStore(t *testing.T) {
+	s, err := newInterruptedStore(t.TempDir(), 1, "relayer")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msgs, err := s.pending()
+	if err != nil || len(msgs) != 0 {
+		t.Fatalf("Got: %v, %v Expected no messages", msgs, err)
+	}
+
+	rId := msg.ResourceId{31: 1}
+	first := msg.NewFungibleTransfer(2, 1, 1, big.NewInt(10), rId, []byte{0xab})
+	second := msg.NewFungibleTransfer(2, 1, 2, big.NewInt(10), rId, []byte{0xab})
+	// A message interrupted again is only recorded once
+	for _, m := range []msg.Message{first, second, first} {
+		if err = s.add(m); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	msgs, err = s.pending()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(msgs) != 2 || msgs[0].DepositNonce != 1 || msgs[1].DepositNonce != 2 || msgs[1].ResourceId != rId {
+		t.Fatalf("Got: %v Expected the two interrupted messages", msgs)
+	}
+
+	// Messages stay recorded until removed
+	if err = s.remove(first); err != nil {
+		t.Fatal(err)
+	}
+	msgs, err = s.pending()
+	if err != nil || len(msgs) != 1 || msgs[0].DepositNonce != 2 {
+		t.Fatalf("Got: %v, %v Expected the second message", msgs, err)
+	}
+
+	if err = s.remove(second); err != nil {
+		t.Fatal(err)
+	}
+	msgs, err = s.pending()
+	if err != nil || len(msgs) != 0 {
+		t.Fatalf("Got: %v, %v Expected no messages", msgs, err)
+	}
+}
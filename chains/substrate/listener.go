@@ -6,17 +6,19 @@ package substrate
 import (
 	"errors"
 	"fmt"
-	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
 	"math/big"
 	"time"
 
 	"github.com/ChainSafe/ChainBridge/chains"
+	"github.com/ChainSafe/ChainBridge/chains/deadletter"
+	"github.com/ChainSafe/ChainBridge/chains/policy"
 	"github.com/ChainSafe/log15"
 	"github.com/centrifuge/chainbridge-utils/blockstore"
 	metrics "github.com/centrifuge/chainbridge-utils/metrics/types"
 	"github.com/centrifuge/chainbridge-utils/msg"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/retriever"
-	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/chain"
 )
 
 type listener struct {
@@ -33,9 +35,15 @@ type listener struct {
 	latestBlock    metrics.LatestBlock
 	metrics        *metrics.ChainMetrics
 	eventRetriever retriever.EventRetriever
+	fetchWorkers   int               // Number of blocks fetched in parallel
+	fetchWindow    uint64            // Maximum number of blocks fetched ahead of processing
+	resources      *resourceCache    // Resource cache of the writer, invalidated on resource changes
+	resourceEvents []eventName       // Events that signal a change of the registered resources
+	policies       *policy.Policies  // Filters deposits before they are routed, nil if unset
+	deadLetters    deadletter.Storer // Records deposits rejected by a policy
 }
 
-// Frequency of polling for a new block
+// Delay before resubscribing or retrying a failed request
 var BlockRetryInterval = time.Second * 5
 var BlockRetryLimit = 5
 
@@ -64,6 +72,8 @@ func NewListener(
 		latestBlock:    metrics.LatestBlock{LastUpdated: time.Now()},
 		metrics:        m,
 		eventRetriever: eventRetriever,
+		fetchWorkers:   DefaultFetchWorkers,
+		fetchWindow:    DefaultFetchWindow,
 	}
 }
 
@@ -108,11 +118,16 @@ func (l *listener) registerEventHandler(name eventName, handler eventHandler) er
 	return nil
 }
 
-var ErrBlockNotReady = errors.New("required result to be 32 bytes, but got 0")
+var ErrPollingTerminated = errors.New("polling terminated")
 
-// pollBlocks will poll for the latest block and proceed to parse the associated events as it sees new blocks.
-// Polling begins at the block defined in `l.startBlock`. Failed attempts to fetch the latest block or parse
-// a block will be retried up to BlockRetryLimit times before returning with an error.
+// errSubscriptionClosed is returned when the subscription ends without error, eg. when the client reconnects
+var errSubscriptionClosed = errors.New("finalized heads subscription closed")
+
+// pollBlocks subscribes to finalized heads and processes every block up to the latest finalized head.
+// Processing begins at the block defined in `l.startBlock`. Blocks between two heads are fetched by their hash,
+// so gaps are filled. If the subscription ends or a block cannot be processed, the listener resubscribes and
+// backfills from the last processed block. Failures are retried up to BlockRetryLimit times without progress before
+// returning with an error, subscriptions closed by a reconnect are not counted.
 func (l *listener) pollBlocks() error {
 	l.log.Info("Polling Blocks...")
 	var currentBlock = l.startBlock
@@ -120,123 +135,162 @@ func (l *listener) pollBlocks() error {
 	for {
 		select {
 		case <-l.stop:
-			return errors.New("polling terminated")
+			return ErrPollingTerminated
 		default:
-			// No more retries, goto next block
+			// No more retries, shut down
 			if retry == 0 {
 				l.sysErr <- fmt.Errorf("event polling retries exceeded (chain=%d, name=%s)", l.chainId, l.name)
 				return nil
 			}
 
-			// Get finalized block hash
-			finalizedHash, err := l.conn.api.RPC.Chain.GetFinalizedHead()
+			sub, err := l.conn.api.RPC.Chain.SubscribeFinalizedHeads()
 			if err != nil {
-				l.log.Error("Failed to fetch finalized hash", "err", err)
+				l.log.Error("Failed to subscribe to finalized heads", "err", err)
 				retry--
 				time.Sleep(BlockRetryInterval)
 				continue
 			}
 
-			// Get finalized block header
-			finalizedHeader, err := l.conn.api.RPC.Chain.GetHeader(finalizedHash)
-			if err != nil {
-				l.log.Error("Failed to fetch finalized header", "err", err)
-				retry--
-				time.Sleep(BlockRetryInterval)
-				continue
+			processed, err := l.followFinalizedHeads(sub, currentBlock)
+			sub.Unsubscribe()
+			if errors.Is(err, ErrPollingTerminated) {
+				return err
 			}
 
-			if l.metrics != nil {
-				l.metrics.LatestKnownBlock.Set(float64(finalizedHeader.Number))
-			}
-
-			// Sleep if the block we want comes after the most recently finalized block
-			if currentBlock > uint64(finalizedHeader.Number) {
-				l.log.Debug("Block not yet finalized", "target", currentBlock, "latest", finalizedHeader.Number)
-				time.Sleep(BlockRetryInterval)
-				continue
+			// Only count failures that occur without progress
+			if processed > currentBlock {
+				retry = BlockRetryLimit
 			}
+			currentBlock = processed
 
-			// Get hash for latest block, sleep and retry if not ready
-			hash, err := l.conn.api.RPC.Chain.GetBlockHash(currentBlock)
-			if err != nil && err.Error() == ErrBlockNotReady.Error() {
-				time.Sleep(BlockRetryInterval)
-				continue
-			} else if err != nil {
-				l.log.Error("Failed to query latest block", "block", currentBlock, "err", err)
+			if errors.Is(err, errSubscriptionClosed) {
+				l.log.Debug("Finalized heads subscription closed, resubscribing", "block", currentBlock)
+			} else {
+				l.log.Error("Finalized heads subscription failed, resubscribing", "block", currentBlock, "err", err)
 				retry--
-				time.Sleep(BlockRetryInterval)
-				continue
 			}
+			time.Sleep(BlockRetryInterval)
+		}
+	}
+}
 
-			l.log.Debug("Querying block for deposit events", "target", currentBlock)
-
-			err = l.processEvents(hash)
-			if err != nil {
-				l.log.Error("Failed to process events in block", "block", currentBlock, "err", err)
-				retry--
-				continue
+// followFinalizedHeads processes blocks as finality advances, starting at currentBlock. It returns the next block
+// to process along with the error that ended the subscription.
+func (l *listener) followFinalizedHeads(sub *chain.FinalizedHeadsSubscription, currentBlock uint64) (uint64, error) {
+	for {
+		select {
+		case <-l.stop:
+			return currentBlock, ErrPollingTerminated
+		case err, ok := <-sub.Err():
+			if !ok || err == nil {
+				return currentBlock, errSubscriptionClosed
 			}
-
-			// Write to blockstore
-			err = l.blockstore.StoreBlock(big.NewInt(0).SetUint64(currentBlock))
-			if err != nil {
-				l.log.Error("Failed to write to blockstore", "err", err)
+			return currentBlock, err
+		case header, ok := <-sub.Chan():
+			if !ok {
+				return currentBlock, errSubscriptionClosed
 			}
 
 			if l.metrics != nil {
-				l.metrics.BlocksProcessed.Inc()
-				l.metrics.LatestProcessedBlock.Set(float64(currentBlock))
+				l.metrics.LatestKnownBlock.Set(float64(header.Number))
 			}
 
-			currentBlock++
-			l.latestBlock.Height = big.NewInt(0).SetUint64(currentBlock)
-			l.latestBlock.LastUpdated = time.Now()
-			retry = BlockRetryLimit
+			// Track runtime upgrades before the blocks are decoded, this also keeps the metadata used by the writer current
+			if err := l.conn.syncMetadata(uint64(header.Number)); err != nil {
+				return currentBlock, fmt.Errorf("failed to sync metadata: %w", err)
+			}
+
+			for currentBlock <= uint64(header.Number) {
+				select {
+				case <-l.stop:
+					return currentBlock, ErrPollingTerminated
+				default:
+				}
+
+				end := currentBlock + l.fetchWindow - 1
+				if end > uint64(header.Number) {
+					end = uint64(header.Number)
+				}
+
+				var err error
+				currentBlock, err = l.processBlocks(currentBlock, end)
+				if err != nil {
+					return currentBlock, err
+				}
+			}
 		}
 	}
 }
 
-// processEvents fetches a block and parses out the events, calling Listener.handleEvents()
-func (l *listener) processEvents(hash types.Hash) error {
-	l.log.Trace("Fetching events for block", "hash", hash.Hex())
+// processBlocks fetches the events of the blocks in [start, end] in parallel and handles them in block order.
+// The blockstore only advances past blocks that were fully processed. Returns the next block to process.
+func (l *listener) processBlocks(start, end uint64) (uint64, error) {
+	l.log.Debug("Querying blocks for deposit events", "start", start, "end", end)
 
-	events, err := l.eventRetriever.GetEvents(hash)
+	for _, block := range l.fetchBlocks(start, end) {
+		if block.err != nil {
+			return block.number, fmt.Errorf("failed to process block %d: %w", block.number, block.err)
+		}
 
-	if err != nil {
-		return fmt.Errorf("event retrieving error: %w", err)
-	}
+		l.handleEvents(block.events)
+		l.log.Trace("Finished processing events", "block", block.hash.Hex())
 
-	l.handleEvents(events)
-	l.log.Trace("Finished processing events", "block", hash.Hex())
+		// Write to blockstore
+		err := l.blockstore.StoreBlock(big.NewInt(0).SetUint64(block.number))
+		if err != nil {
+			l.log.Error("Failed to write to blockstore", "err", err)
+		}
 
-	return nil
+		if l.metrics != nil {
+			l.metrics.BlocksProcessed.Inc()
+			l.metrics.LatestProcessedBlock.Set(float64(block.number))
+		}
+
+		l.latestBlock.Height = big.NewInt(0).SetUint64(block.number + 1)
+		l.latestBlock.LastUpdated = time.Now()
+	}
+	return end + 1, nil
 }
 
-const MetadataUpdateEvent = "ParachainSystem.ValidationFunctionApplied"
+// Events signalling a runtime upgrade, for parachains and solo chains respectively
+const (
+	MetadataUpdateEvent = "ParachainSystem.ValidationFunctionApplied"
+	CodeUpdatedEvent    = "System.CodeUpdated"
+)
 
 // handleEvents calls the associated handler for all registered event types
 func (l *listener) handleEvents(events []*parser.Event) {
+	names := l.conn.names
 	for _, event := range events {
 		switch {
-		case l.subscriptions[FungibleTransfer] != nil && event.Name == string(FungibleTransfer):
+		case l.subscriptions[FungibleTransfer] != nil && event.Name == string(names.fungibleTransfer):
 			l.log.Debug("Handling FungibleTransfer event")
-			l.submitMessage(l.subscriptions[FungibleTransfer](event.Fields, l.log))
-		case l.subscriptions[NonFungibleTransfer] != nil && event.Name == string(NonFungibleTransfer):
+			l.submitMessage(l.subscriptions[FungibleTransfer](event.Fields, names, l.log))
+		case l.subscriptions[NonFungibleTransfer] != nil && event.Name == string(names.nonFungibleTransfer):
 			l.log.Debug("Handling NonFungibleTransfer event")
-			l.submitMessage(l.subscriptions[NonFungibleTransfer](event.Fields, l.log))
-		case l.subscriptions[GenericTransfer] != nil && event.Name == string(GenericTransfer):
+			l.submitMessage(l.subscriptions[NonFungibleTransfer](event.Fields, names, l.log))
+		case l.subscriptions[GenericTransfer] != nil && event.Name == string(names.genericTransfer):
 			l.log.Debug("Handling GenericTransfer event")
-			l.submitMessage(l.subscriptions[GenericTransfer](event.Fields, l.log))
-		case event.Name == MetadataUpdateEvent:
-			l.log.Debug("Received metadata update event")
-
-			if err := l.conn.updateMetadata(); err != nil {
-				l.log.Error("Unable to update metadata", "error", err)
-			}
+			l.submitMessage(l.subscriptions[GenericTransfer](event.Fields, names, l.log))
+		case l.resources != nil && l.isResourceEvent(event.Name):
+			l.log.Debug("Received resource change event, invalidating resource cache", "event", event.Name)
+			l.resources.invalidate()
+		case event.Name == MetadataUpdateEvent || event.Name == CodeUpdatedEvent:
+			// The metadata is updated as finalized heads are followed, the resource cache is dropped once the writer
+			// sees the new spec version
+			l.log.Debug("Received runtime upgrade event", "event", event.Name)
 		}
 	}
+}
 
+// isResourceEvent returns true if the event signals a change of the registered resources
+func (l *listener) isResourceEvent(name string) bool {
+	for _, e := range l.resourceEvents {
+		if string(e) == name {
+			return true
+		}
+	}
+	return false
 }
 
 // submitMessage inserts the chainId into the msg and sends it to the router
@@ -246,6 +300,14 @@ func (l *listener) submitMessage(m msg.Message, err error) {
 		return
 	}
 	m.Source = l.chainId
+	// Senders are not part of the transfer events
+	if err = l.policies.Check(m, nil); err != nil {
+		l.log.Warn("Deposit rejected by policy, not relaying", "dest", m.Destination, "nonce", m.DepositNonce, "err", err)
+		if err = l.deadLetters.StoreMessage(m, err); err != nil {
+			l.log.Error("Failed to record rejected deposit", "nonce", m.DepositNonce, "err", err)
+		}
+		return
+	}
 	err = l.router.Send(m)
 	if err != nil {
 		log15.Error("failed to process event", "err", err)
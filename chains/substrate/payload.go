@@ -0,0 +1,142 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package substrate
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// callFields returns the argument fields of the method as declared in the metadata
+func callFields(meta *types.Metadata, method string) ([]types.Si1Field, error) {
+	parts := strings.Split(method, ".")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid method name %s", method)
+	}
+
+	for _, mod := range meta.AsMetadataV14.Pallets {
+		if string(mod.Name) != parts[0] || !mod.HasCalls {
+			continue
+		}
+		callsType, ok := meta.AsMetadataV14.EfficientLookup[mod.Calls.Type.Int64()]
+		if !ok || !callsType.Def.IsVariant {
+			return nil, fmt.Errorf("calls type of pallet %s not found", parts[0])
+		}
+		for _, variant := range callsType.Def.Variant.Variants {
+			if string(variant.Name) == parts[1] {
+				return variant.Fields, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("method %s not found in metadata", method)
+}
+
+// resolveType looks up the type, unwrapping composites with a single field such as H256 or BoundedVec
+func resolveType(meta *types.Metadata, id types.Si1LookupTypeID) (*types.Si1Type, error) {
+	typ, ok := meta.AsMetadataV14.EfficientLookup[id.Int64()]
+	if !ok {
+		return nil, fmt.Errorf("type %d not found in metadata", id.Int64())
+	}
+	if typ.Def.IsComposite && len(typ.Def.Composite.Fields) == 1 {
+		return resolveType(meta, typ.Def.Composite.Fields[0].Type)
+	}
+	return typ, nil
+}
+
+// isU8 returns true if the type is the u8 primitive
+func isU8(meta *types.Metadata, id types.Si1LookupTypeID) bool {
+	typ, ok := meta.AsMetadataV14.EfficientLookup[id.Int64()]
+	return ok && typ.Def.IsPrimitive && typ.Def.Primitive.Si0TypeDefPrimitive == types.IsU8
+}
+
+// callRegistries caches the call registry per spec version of the runtime
+type callRegistries struct {
+	registries map[types.U32]registry.CallRegistry
+	lock       sync.Mutex
+}
+
+func newCallRegistries() *callRegistries {
+	return &callRegistries{registries: make(map[types.U32]registry.CallRegistry)}
+}
+
+// get returns the call registry of the spec version, creating it from the metadata if required
+func (c *callRegistries) get(meta *types.Metadata, specVersion types.U32) (registry.CallRegistry, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if calls, ok := c.registries[specVersion]; ok {
+		return calls, nil
+	}
+	calls, err := registry.NewFactory().CreateCallRegistry(meta)
+	if err != nil {
+		return nil, fmt.Errorf("call registry creation (specVersion=%d): %w", specVersion, err)
+	}
+	c.registries[specVersion] = calls
+	return calls, nil
+}
+
+// encodeArgs encodes the payload of a generic transfer as the arguments of the method, according to the
+// argument types in the metadata. A single argument of type [u8; N] (eg. H256) takes the payload as is, if it is
+// exactly N bytes long. A single Vec<u8> argument takes the payload with its length prefixed. Otherwise the payload
+// must be the SCALE encoding of all arguments. If extended is set, the last argument is the resource ID and is not
+// part of the payload.
+func (c *callRegistries) encodeArgs(meta *types.Metadata, specVersion types.U32, method string, payload []byte, extended bool) ([]byte, error) {
+	fields, err := callFields(meta, method)
+	if err != nil {
+		return nil, err
+	}
+	if extended {
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("method %s has no resource ID argument", method)
+		}
+		fields = fields[:len(fields)-1]
+	}
+
+	if len(fields) == 1 {
+		typ, err := resolveType(meta, fields[0].Type)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case typ.Def.IsArray && isU8(meta, typ.Def.Array.Type):
+			if len(payload) != int(typ.Def.Array.Len) {
+				return nil, fmt.Errorf("payload of %d bytes does not fit [u8; %d] argument of %s", len(payload), typ.Def.Array.Len, method)
+			}
+			return payload, nil
+		case typ.Def.IsSequence && isU8(meta, typ.Def.Sequence.Type):
+			return codec.Encode(types.NewBytes(payload))
+		}
+	}
+
+	// Decode the payload as SCALE encoded arguments to ensure it matches the argument types
+	calls, err := c.get(meta, specVersion)
+	if err != nil {
+		return nil, err
+	}
+	callIndex, err := meta.FindCallIndex(method)
+	if err != nil {
+		return nil, err
+	}
+	callDecoder, ok := calls[callIndex]
+	if !ok {
+		return nil, fmt.Errorf("method %s not found in call registry", method)
+	}
+	argDecoder := &registry.TypeDecoder{Name: callDecoder.Name, Fields: callDecoder.Fields[:len(fields)]}
+
+	reader := bytes.NewReader(payload)
+	if _, err := argDecoder.Decode(scale.NewDecoder(reader)); err != nil {
+		return nil, fmt.Errorf("payload does not match arguments of %s: %w", method, err)
+	}
+	if reader.Len() != 0 {
+		return nil, fmt.Errorf("payload has %d bytes left after decoding arguments of %s", reader.Len(), method)
+	}
+	return payload, nil
+}
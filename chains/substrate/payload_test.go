@@ -0,0 +1,77 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package substrate
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+func TestCallRegistries_EncodeArgs(t *testing.T) {
+	var meta types.Metadata
+	err := codec.DecodeFromHex(types.MetadataV14Data, &meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hash := bytes.Repeat([]byte{0xab}, 32)
+	remark := []byte("a remark of arbitrary length")
+	encRemark, err := codec.Encode(types.NewBytes(remark))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Democracy.propose(proposal_hash: H256, value: Compact<Balance>)
+	encValue, err := codec.Encode(types.NewUCompactFromUInt(1000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	proposeArgs := append(append([]byte{}, hash...), encValue...)
+
+	testCases := []struct {
+		name     string
+		method   string
+		payload  []byte
+		extended bool
+		expected []byte
+		err      bool
+	}{
+		{name: "vec", method: "System.remark", payload: remark, expected: encRemark},
+		{name: "empty vec", method: "System.remark", payload: []byte{}, expected: []byte{0}},
+		{name: "hash", method: "Preimage.unnote_preimage", payload: hash, expected: hash},
+		{name: "short hash", method: "Preimage.unnote_preimage", payload: hash[:31], err: true},
+		{name: "long hash", method: "Preimage.unnote_preimage", payload: append(hash, 0), err: true},
+		{name: "struct", method: "Democracy.propose", payload: proposeArgs, expected: proposeArgs},
+		{name: "struct trailing bytes", method: "Democracy.propose", payload: append(proposeArgs, 0), err: true},
+		{name: "struct truncated", method: "Democracy.propose", payload: hash, err: true},
+		{name: "extended", method: "Democracy.propose", payload: hash, extended: true, expected: hash},
+		{name: "unknown method", method: "Example.unknown", payload: hash, err: true},
+	}
+
+	calls := newCallRegistries()
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := calls.encodeArgs(&meta, 1, tc.method, tc.payload, tc.extended)
+			if tc.err {
+				if err == nil {
+					t.Fatalf("expected error, got: %x", res)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(res, tc.expected) {
+				t.Fatalf("Got: %x Expected: %x", res, tc.expected)
+			}
+		})
+	}
+
+	// Only the structured arguments require the call registry
+	if len(calls.registries) != 1 {
+		t.Fatalf("Got: %d Expected: %d registries", len(calls.registries), 1)
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package substrate
+
+import (
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const (
+	ProxyCallMethod    = "Proxy.proxy"
+	ProxyExecutedEvent = "Proxy.ProxyExecuted"
+)
+
+// proxyCall wraps the call in Proxy.proxy, dispatching it with the origin of the real account.
+// No proxy type is forced, the runtime picks the first proxy definition that permits the call.
+func proxyCall(meta *types.Metadata, real types.AccountID, call types.Call) (types.Call, error) {
+	address, err := types.NewMultiAddressFromAccountID(real[:])
+	if err != nil {
+		return types.Call{}, err
+	}
+	return types.NewCall(meta, ProxyCallMethod, address, types.NewOptionU8Empty(), call)
+}
+
+// findDispatchError searches decoded event fields for a DispatchError, as found in the Err variant of a
+// DispatchResult. Returns false if the fields do not contain an error.
+func findDispatchError(value interface{}) (types.DispatchError, bool) {
+	switch v := value.(type) {
+	case types.DispatchError:
+		return v, true
+	case registry.DecodedFields:
+		for _, field := range v {
+			if dispatchErr, ok := findDispatchError(field); ok {
+				return dispatchErr, true
+			}
+		}
+	case *registry.DecodedField:
+		if v != nil {
+			return findDispatchError(v.Value)
+		}
+	}
+	return types.DispatchError{}, false
+}
@@ -0,0 +1,36 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package substrate
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+func TestFindDispatchError(t *testing.T) {
+	// Ok(()) of a DispatchResult
+	ok := registry.DecodedFields{
+		&registry.DecodedField{Name: "result", Value: registry.DecodedFields{}},
+	}
+	if _, found := findDispatchError(ok); found {
+		t.Fatal("unexpected dispatch error in successful result")
+	}
+
+	// Err(DispatchError) of a DispatchResult
+	expected := types.DispatchError{IsBadOrigin: true}
+	failed := registry.DecodedFields{
+		&registry.DecodedField{Name: "result", Value: registry.DecodedFields{
+			&registry.DecodedField{Value: expected},
+		}},
+	}
+	dispatchErr, found := findDispatchError(failed)
+	if !found {
+		t.Fatal("expected dispatch error")
+	}
+	if !dispatchErr.IsBadOrigin {
+		t.Fatalf("Got: %#v Expected: %#v", dispatchErr, expected)
+	}
+}
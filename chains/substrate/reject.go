@@ -0,0 +1,89 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package substrate
+
+import (
+	"fmt"
+
+	"github.com/ChainSafe/ChainBridge/chains/recipient"
+	"github.com/centrifuge/chainbridge-utils/msg"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const rejectProposal = "reject_proposal"
+
+// RemarkMethod is used to construct the placeholder call of rejected proposals
+const RemarkMethod = "System.remark"
+
+// Reasons for rejecting a proposal. These are part of the placeholder call, so relayers that reject a message
+// for the same reason vote on the same proposal. Proposals of unknown resources cannot be rejected, as the pallet
+// requires the resource to exist.
+const (
+	ReasonUnknownResource  = "unknown_resource"
+	ReasonInvalidRecipient = "invalid_recipient"
+	ReasonInvalidPayload   = "invalid_payload"
+	ReasonInvalidCall      = "invalid_call"
+	ReasonInvalidAmount    = "invalid_amount"
+)
+
+// ValidationError is returned if a message fails deterministic validation. Retrying cannot succeed, so the
+// proposal is rejected on chain instead if its resource exists.
+type ValidationError struct {
+	Reason string // One of the Reason constants
+	Err    error  // Details of the failure
+}
+
+func newValidationError(reason string, err error) *ValidationError {
+	return &ValidationError{Reason: reason, Err: err}
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid proposal (%s): %s", e.Reason, e.Err)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// RejectReason returns the machine readable reason for the rejection
+func (e *ValidationError) RejectReason() string {
+	return e.Reason
+}
+
+// rejectable returns true if the proposal can be voted against, which requires its resource to exist
+func (e *ValidationError) rejectable() bool {
+	return e.Reason != ReasonUnknownResource
+}
+
+// createRejectionProposal constructs the proposal voted against for an invalid message. As no valid call exists,
+// a remark of the rejection reason is used as placeholder call.
+func (w *writer) createRejectionProposal(m msg.Message, invalid *ValidationError) (*proposal, error) {
+	meta := w.conn.getMetadata()
+	call, err := types.NewCall(&meta, RemarkMethod, types.NewBytes([]byte(invalid.Reason)))
+	if err != nil {
+		return nil, err
+	}
+
+	return &proposal{
+		depositNonce: types.U64(m.DepositNonce),
+		call:         call,
+		sourceId:     types.U8(m.Source),
+		resourceId:   types.NewBytes32(m.ResourceId),
+		method:       RemarkMethod,
+	}, nil
+}
+
+// decodeRecipient returns the account ID of a recipient. Recipients are either 32 byte account IDs or SS58 addresses.
+// SS58 addresses must be encoded for the network, if set.
+func decodeRecipient(raw []byte, network *uint16) (*types.AccountID, error) {
+	rec, err := recipient.Substrate{Network: network}.Decode(raw)
+	if err != nil {
+		return nil, newValidationError(ReasonInvalidRecipient, err)
+	}
+	accountID, err := types.NewAccountID(rec)
+	if err != nil {
+		return nil, newValidationError(ReasonInvalidRecipient, err)
+	}
+	return accountID, nil
+}
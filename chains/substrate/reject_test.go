@@ -0,0 +1,99 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package substrate
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ChainSafe/ChainBridge/chains/payload"
+	"github.com/centrifuge/chainbridge-utils/msg"
+)
+
+func TestCreateProposal_InvalidPayload(t *testing.T) {
+	w := &writer{}
+	create := map[msg.TransferType]func(msg.Message) (*proposal, error){
+		msg.FungibleTransfer:         w.createFungibleProposal,
+		msg.NonFungibleTransfer:      w.createNonFungibleProposal,
+		msg.GenericTransfer:          w.createGenericProposal,
+		payload.SemiFungibleTransfer: w.createSemiFungibleProposal,
+	}
+
+	// Malformed messages must fail validation before any chain state is queried
+	for transferType, f := range create {
+		m := msg.Message{Type: transferType, Payload: []interface{}{"not bytes", 1, nil}}
+		_, err := f(m)
+		var invalid *ValidationError
+		if !errors.As(err, &invalid) || invalid.Reason != ReasonInvalidPayload {
+			t.Fatalf("%s: expected invalid payload error, got: %v", transferType, err)
+		}
+	}
+}
+
+func TestCreateSemiFungibleProposal_AmountOverflow(t *testing.T) {
+	w := &writer{}
+	p := &payload.SemiFungible{
+		TokenIds:  []*big.Int{big.NewInt(1)},
+		Amounts:   []*big.Int{new(big.Int).Lsh(big.NewInt(1), 128)},
+		Recipient: make([]byte, 32),
+	}
+
+	_, err := w.createSemiFungibleProposal(p.Message(1, 2, 3, msg.ResourceId{}))
+	var invalid *ValidationError
+	if !errors.As(err, &invalid) || invalid.Reason != ReasonInvalidAmount {
+		t.Fatalf("expected invalid amount error, got: %v", err)
+	}
+}
+
+func TestDecodeRecipient(t *testing.T) {
+	alice := "d43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d"
+
+	substrate, kusama := uint16(42), uint16(2)
+
+	rec, err := decodeRecipient([]byte("5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"), &substrate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprintf("%x", rec[:]) != alice {
+		t.Fatalf("Got: %x Expected: %s", rec[:], alice)
+	}
+
+	for _, tc := range []struct {
+		raw     []byte
+		network *uint16
+	}{
+		{raw: make([]byte, 20)},
+		{raw: []byte("5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"), network: &kusama},
+	} {
+		_, err = decodeRecipient(tc.raw, tc.network)
+		var invalid *ValidationError
+		if !errors.As(err, &invalid) || invalid.Reason != ReasonInvalidRecipient {
+			t.Fatalf("expected invalid recipient error, got: %v", err)
+		}
+	}
+}
+
+func TestValidationError(t *testing.T) {
+	cause := errors.New("bad length")
+	err := fmt.Errorf("failed to construct proposal: %w", newValidationError(ReasonInvalidRecipient, cause))
+
+	var invalid *ValidationError
+	if !errors.As(err, &invalid) {
+		t.Fatal("expected validation error")
+	}
+	if invalid.RejectReason() != ReasonInvalidRecipient {
+		t.Fatalf("Got: %s Expected: %s", invalid.RejectReason(), ReasonInvalidRecipient)
+	}
+	if !errors.Is(err, cause) {
+		t.Fatal("expected cause to be unwrapped")
+	}
+	if !invalid.rejectable() {
+		t.Fatal("expected invalid recipient to be rejectable")
+	}
+	if newValidationError(ReasonUnknownResource, cause).rejectable() {
+		t.Fatal("expected unknown resource not to be rejectable")
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package substrate
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// DefaultResourceCacheTTL is the time resolved resource methods are cached for
+const DefaultResourceCacheTTL = 10 * time.Minute
+
+// Default events of the bridge pallet that signal a change of the registered resources
+var DefaultResourceEvents = []string{"ResourceSet", "ResourceRemoved"}
+
+type resourceEntry struct {
+	method  string
+	expires time.Time
+}
+
+// resourceCache maps resource IDs to the method names registered in the bridge pallet. Entries expire after the
+// TTL, and all entries are dropped if the runtime is upgraded or the listener sees a resource change.
+type resourceCache struct {
+	ttl         time.Duration
+	entries     map[[32]byte]resourceEntry
+	specVersion types.U32 // Spec version of the runtime the entries were resolved with
+	lock        sync.Mutex
+	hits        prometheus.Counter // Nil if metrics are disabled
+	misses      prometheus.Counter // Nil if metrics are disabled
+}
+
+func newResourceCache(ttl time.Duration) *resourceCache {
+	return &resourceCache{
+		ttl:     ttl,
+		entries: make(map[[32]byte]resourceEntry),
+	}
+}
+
+// registerMetrics creates and registers the hit and miss counters of the cache
+func (c *resourceCache) registerMetrics(chain string) {
+	c.hits = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: fmt.Sprintf("%s_resource_cache_hits", chain),
+		Help: "Number of resource IDs resolved from the writer's cache",
+	})
+	c.misses = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: fmt.Sprintf("%s_resource_cache_misses", chain),
+		Help: "Number of resource IDs resolved with a storage query",
+	})
+	prometheus.MustRegister(c.hits)
+	prometheus.MustRegister(c.misses)
+}
+
+// get returns the cached method of the resource, if it has not expired and was resolved with the given spec version
+func (c *resourceCache) get(id [32]byte, specVersion types.U32) (string, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if specVersion != c.specVersion {
+		c.entries = make(map[[32]byte]resourceEntry)
+		c.specVersion = specVersion
+	}
+
+	entry, ok := c.entries[id]
+	if ok && time.Now().After(entry.expires) {
+		delete(c.entries, id)
+		ok = false
+	}
+
+	if ok && c.hits != nil {
+		c.hits.Inc()
+	} else if !ok && c.misses != nil {
+		c.misses.Inc()
+	}
+	return entry.method, ok
+}
+
+// set caches the method of the resource as resolved with the given spec version
+func (c *resourceCache) set(id [32]byte, method string, specVersion types.U32) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if specVersion != c.specVersion || c.ttl <= 0 {
+		return
+	}
+	c.entries[id] = resourceEntry{method: method, expires: time.Now().Add(c.ttl)}
+}
+
+// invalidate drops all entries
+func (c *resourceCache) invalidate() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.entries = make(map[[32]byte]resourceEntry)
+}
@@ -0,0 +1,67 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package substrate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestResourceCache(t *testing.T) {
+	cache := newResourceCache(time.Minute)
+	cache.hits = prometheus.NewCounter(prometheus.CounterOpts{Name: "hits"})
+	cache.misses = prometheus.NewCounter(prometheus.CounterOpts{Name: "misses"})
+	id := [32]byte{1}
+
+	if _, ok := cache.get(id, 1); ok {
+		t.Fatal("unexpected cache hit")
+	}
+	cache.set(id, "Example.transfer", 1)
+	if method, ok := cache.get(id, 1); !ok || method != "Example.transfer" {
+		t.Fatalf("Got: %s Expected: %s", method, "Example.transfer")
+	}
+
+	// A new spec version drops all entries
+	if _, ok := cache.get(id, 2); ok {
+		t.Fatal("unexpected cache hit after runtime upgrade")
+	}
+	cache.set(id, "Example.transfer", 1)
+	if _, ok := cache.get(id, 2); ok {
+		t.Fatal("entries of previous spec versions must not be cached")
+	}
+
+	cache.set(id, "Example.transfer", 2)
+	cache.invalidate()
+	if _, ok := cache.get(id, 2); ok {
+		t.Fatal("unexpected cache hit after invalidation")
+	}
+
+	if hits := testutil.ToFloat64(cache.hits); hits != 1 {
+		t.Fatalf("Got: %v Expected: %v hits", hits, 1)
+	}
+	if misses := testutil.ToFloat64(cache.misses); misses != 4 {
+		t.Fatalf("Got: %v Expected: %v misses", misses, 4)
+	}
+}
+
+func TestResourceCache_Expiry(t *testing.T) {
+	id := [32]byte{1}
+
+	cache := newResourceCache(time.Millisecond)
+	cache.set(id, "Example.transfer", 0)
+	time.Sleep(5 * time.Millisecond)
+	if _, ok := cache.get(id, 0); ok {
+		t.Fatal("unexpected cache hit for expired entry")
+	}
+
+	// A TTL of 0 disables the cache
+	cache = newResourceCache(0)
+	cache.set(id, "Example.transfer", 0)
+	if _, ok := cache.get(id, 0); ok {
+		t.Fatal("unexpected cache hit with caching disabled")
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package substrate
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/retriever"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// U256TypePath is the path of the U256 type in the type registry of the runtime
+var U256TypePath = []string{"primitive_types", "U256"}
+
+var _ retriever.EventRetriever = &versionedEventRetriever{}
+var _ blockEventRetriever = &versionedEventRetriever{}
+
+// versionedEventRetriever decodes the events of a block with the metadata of the runtime that applied at that block.
+// An event registry is created once per spec version. It is safe for concurrent use.
+type versionedEventRetriever struct {
+	conn       *Connection
+	provider   state.EventProvider
+	parser     parser.EventParser
+	registries map[types.U32]registry.EventRegistry
+	lock       sync.Mutex
+}
+
+// newEventRetriever creates an event retriever and the event registry for the current metadata of the connection.
+func newEventRetriever(conn *Connection) (retriever.EventRetriever, error) {
+	r := &versionedEventRetriever{
+		conn:       conn,
+		provider:   state.NewEventProvider(conn.api.RPC.State),
+		parser:     parser.NewEventParser(),
+		registries: make(map[types.U32]registry.EventRegistry),
+	}
+
+	meta, specVersion := conn.getVersionedMetadata()
+	if _, err := r.eventRegistry(&meta, specVersion); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// GetEvents retrieves and decodes the events of the block with the given hash using the latest metadata. It is
+// meant for blocks at the head of the chain, use GetBlockEvents for historical blocks.
+func (r *versionedEventRetriever) GetEvents(blockHash types.Hash) ([]*parser.Event, error) {
+	meta, specVersion := r.conn.getVersionedMetadata()
+	return r.events(&meta, specVersion, blockHash)
+}
+
+// GetBlockEvents retrieves and decodes the events of a block with the metadata of the runtime that applied at it
+func (r *versionedEventRetriever) GetBlockEvents(block uint64, blockHash types.Hash) ([]*parser.Event, error) {
+	meta, specVersion, err := r.conn.metadataAt(block)
+	if err != nil {
+		return nil, err
+	}
+	return r.events(meta, specVersion, blockHash)
+}
+
+func (r *versionedEventRetriever) events(meta *types.Metadata, specVersion types.U32, blockHash types.Hash) ([]*parser.Event, error) {
+	eventRegistry, err := r.eventRegistry(meta, specVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	storageEvents, err := r.provider.GetStorageEvents(meta, blockHash)
+	if err != nil {
+		return nil, fmt.Errorf("event storage retrieval: %w", err)
+	}
+
+	events, err := r.parser.ParseEvents(eventRegistry, storageEvents)
+	if err != nil {
+		return nil, fmt.Errorf("event parsing (specVersion=%d): %w", specVersion, err)
+	}
+	return events, nil
+}
+
+// eventRegistry returns the event registry of a spec version, creating it from the metadata if required.
+// u256 is represented as [u64;4]. We override its lookup index to skip extra processing when decoding fields with
+// this type. DispatchError is overridden so failed extrinsics can be matched to their error in the metadata.
+// The indices are resolved from the metadata, as they change whenever the type registry is reordered.
+func (r *versionedEventRetriever) eventRegistry(meta *types.Metadata, specVersion types.U32) (registry.EventRegistry, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if eventRegistry, ok := r.registries[specVersion]; ok {
+		return eventRegistry, nil
+	}
+
+	u256LookupIndex, err := findTypeLookupIndex(meta, U256TypePath)
+	if err != nil {
+		return nil, err
+	}
+	dispatchErrorLookupIndex, err := findTypeLookupIndex(meta, DispatchErrorTypePath)
+	if err != nil {
+		return nil, err
+	}
+
+	u256FieldOverride := registry.FieldOverride{
+		FieldLookupIndex: u256LookupIndex,
+		FieldDecoder:     &registry.ValueDecoder[types.U256]{},
+	}
+	dispatchErrorFieldOverride := registry.FieldOverride{
+		FieldLookupIndex: dispatchErrorLookupIndex,
+		FieldDecoder:     &registry.ValueDecoder[types.DispatchError]{},
+	}
+
+	eventRegistry, err := registry.NewFactory(u256FieldOverride, dispatchErrorFieldOverride).CreateEventRegistry(meta)
+	if err != nil {
+		return nil, fmt.Errorf("event registry creation (specVersion=%d): %w", specVersion, err)
+	}
+
+	r.conn.log.Debug("Created event registry", "specVersion", specVersion, "u256LookupIndex", u256LookupIndex)
+	r.registries[specVersion] = eventRegistry
+	return eventRegistry, nil
+}
@@ -0,0 +1,56 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package substrate
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ChainSafe/log15"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+func TestVersionedEventRetriever_EventRegistry(t *testing.T) {
+	var meta types.Metadata
+	err := codec.DecodeFromHex(types.MetadataV14Data, &meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The example metadata does not use U256, add it to the type registry
+	meta.AsMetadataV14.Lookup.Types = append(meta.AsMetadataV14.Lookup.Types, types.PortableTypeV14{
+		ID:   types.NewSi1LookupTypeID(big.NewInt(int64(len(meta.AsMetadataV14.Lookup.Types)))),
+		Type: types.Si1Type{Path: types.Si1Path{"primitive_types", "U256"}},
+	})
+
+	r := &versionedEventRetriever{
+		conn:       &Connection{log: log15.New()},
+		registries: make(map[types.U32]registry.EventRegistry),
+	}
+
+	first, err := r.eventRegistry(&meta, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(first) == 0 {
+		t.Fatal("expected events in registry")
+	}
+
+	// Registries are cached per spec version
+	_, err = r.eventRegistry(&types.Metadata{}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Other spec versions are created from their own metadata
+	_, err = r.eventRegistry(&types.Metadata{}, 2)
+	if err == nil {
+		t.Fatal("expected error for metadata without required types")
+	}
+	if len(r.registries) != 1 {
+		t.Fatalf("Got: %d Expected: %d registries", len(r.registries), 1)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/ChainSafe/ChainBridge/chains/deadletter"
 	utils "github.com/ChainSafe/ChainBridge/shared/substrate"
 	"github.com/ChainSafe/log15"
 	"github.com/centrifuge/chainbridge-utils/keystore"
@@ -109,8 +110,8 @@ func TestMain(m *testing.M) {
 	if err != nil {
 		panic(err)
 	}
-	alice := NewWriter(aliceConn, AliceTestLogger, wSysErr, nil, true)
-	bob := NewWriter(bobConn, BobTestLogger, wSysErr, nil, true)
+	alice := NewWriter(aliceConn, AliceTestLogger, wSysErr, nil, extendedCalls{all: true}, &deadletter.EmptyStore{})
+	bob := NewWriter(bobConn, BobTestLogger, wSysErr, nil, extendedCalls{all: true}, &deadletter.EmptyStore{})
 	context = testContext{
 		client:         client,
 		listener:       l,
@@ -4,9 +4,11 @@
 package substrate
 
 import (
+	"fmt"
+
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
-	"math/big"
 
+	"github.com/ChainSafe/ChainBridge/chains/payload"
 	"github.com/centrifuge/chainbridge-utils/msg"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
@@ -60,19 +62,26 @@ func (p *proposal) encode() ([]byte, error) {
 }
 
 func (w *writer) createFungibleProposal(m msg.Message) (*proposal, error) {
-	bigAmt := big.NewInt(0).SetBytes(m.Payload[0].([]byte))
-	amount := types.NewU128(*bigAmt)
-	recipient, err := types.NewAccountID(m.Payload[1].([]byte))
+	p, err := payload.ParseFungible(m)
+	if err != nil {
+		return nil, newValidationError(ReasonInvalidPayload, err)
+	}
+	recipient, err := decodeRecipient(p.Recipient, w.network)
 	if err != nil {
 		return nil, err
 	}
+	bigAmt, err := w.decimals.Convert(m.Source, m.ResourceId, p.Amount, 128)
+	if err != nil {
+		return nil, newValidationError(ReasonInvalidAmount, err)
+	}
+	amount := types.NewU128(*bigAmt)
 	depositNonce := types.U64(m.DepositNonce)
 
-	meta := w.conn.getMetadata()
 	method, err := w.resolveResourceId(m.ResourceId)
 	if err != nil {
 		return nil, err
 	}
+	meta := w.conn.getMetadata()
 	call, err := types.NewCall(
 		&meta,
 		method,
@@ -80,9 +89,9 @@ func (w *writer) createFungibleProposal(m msg.Message) (*proposal, error) {
 		amount,
 	)
 	if err != nil {
-		return nil, err
+		return nil, newValidationError(ReasonInvalidCall, err)
 	}
-	if w.extendCall {
+	if w.extendCalls.extends(m.ResourceId, method) {
 		eRID, err := codec.Encode(m.ResourceId)
 		if err != nil {
 			return nil, err
@@ -100,19 +109,23 @@ func (w *writer) createFungibleProposal(m msg.Message) (*proposal, error) {
 }
 
 func (w *writer) createNonFungibleProposal(m msg.Message) (*proposal, error) {
-	tokenId := types.NewU256(*big.NewInt(0).SetBytes(m.Payload[0].([]byte)))
-	recipient, err := types.NewAccountID(m.Payload[1].([]byte))
+	p, err := payload.ParseNonFungible(m)
+	if err != nil {
+		return nil, newValidationError(ReasonInvalidPayload, err)
+	}
+	recipient, err := decodeRecipient(p.Recipient, w.network)
 	if err != nil {
 		return nil, err
 	}
-	metadata := types.Bytes(m.Payload[2].([]byte))
+	tokenId := types.NewU256(*p.TokenId)
+	metadata := types.Bytes(p.Metadata)
 	depositNonce := types.U64(m.DepositNonce)
 
-	meta := w.conn.getMetadata()
 	method, err := w.resolveResourceId(m.ResourceId)
 	if err != nil {
 		return nil, err
 	}
+	meta := w.conn.getMetadata()
 
 	call, err := types.NewCall(
 		&meta,
@@ -122,9 +135,9 @@ func (w *writer) createNonFungibleProposal(m msg.Message) (*proposal, error) {
 		metadata,
 	)
 	if err != nil {
-		return nil, err
+		return nil, newValidationError(ReasonInvalidCall, err)
 	}
-	if w.extendCall {
+	if w.extendCalls.extends(m.ResourceId, method) {
 		eRID, err := codec.Encode(m.ResourceId)
 		if err != nil {
 			return nil, err
@@ -141,22 +154,85 @@ func (w *writer) createNonFungibleProposal(m msg.Message) (*proposal, error) {
 	}, nil
 }
 
-func (w *writer) createGenericProposal(m msg.Message) (*proposal, error) {
-	meta := w.conn.getMetadata()
+// createSemiFungibleProposal follows the non-fungible proposal, with the call taking the recipient, the token IDs,
+// their amounts and the transfer data
+func (w *writer) createSemiFungibleProposal(m msg.Message) (*proposal, error) {
+	p, err := payload.ParseSemiFungible(m)
+	if err != nil {
+		return nil, newValidationError(ReasonInvalidPayload, err)
+	}
+	recipient, err := decodeRecipient(p.Recipient, w.network)
+	if err != nil {
+		return nil, err
+	}
+	tokenIds := make([]types.U256, len(p.TokenIds))
+	amounts := make([]types.U128, len(p.Amounts))
+	for i := range p.TokenIds {
+		if p.Amounts[i].BitLen() > 128 {
+			return nil, newValidationError(ReasonInvalidAmount, fmt.Errorf("amount %s of token %s overflows U128", p.Amounts[i], p.TokenIds[i]))
+		}
+		tokenIds[i] = types.NewU256(*p.TokenIds[i])
+		amounts[i] = types.NewU128(*p.Amounts[i])
+	}
+	data := types.Bytes(p.Data)
+	depositNonce := types.U64(m.DepositNonce)
+
 	method, err := w.resolveResourceId(m.ResourceId)
 	if err != nil {
 		return nil, err
 	}
+	meta := w.conn.getMetadata()
 
 	call, err := types.NewCall(
 		&meta,
 		method,
-		types.NewHash(m.Payload[0].([]byte)),
+		recipient,
+		tokenIds,
+		amounts,
+		data,
 	)
+	if err != nil {
+		return nil, newValidationError(ReasonInvalidCall, err)
+	}
+	if w.extendCalls.extends(m.ResourceId, method) {
+		eRID, err := codec.Encode(m.ResourceId)
+		if err != nil {
+			return nil, err
+		}
+		call.Args = append(call.Args, eRID...)
+	}
+
+	return &proposal{
+		depositNonce: depositNonce,
+		call:         call,
+		sourceId:     types.U8(m.Source),
+		resourceId:   types.NewBytes32(m.ResourceId),
+		method:       method,
+	}, nil
+}
+
+func (w *writer) createGenericProposal(m msg.Message) (*proposal, error) {
+	p, err := payload.ParseGeneric(m)
+	if err != nil {
+		return nil, newValidationError(ReasonInvalidPayload, err)
+	}
+	method, err := w.resolveResourceId(m.ResourceId)
 	if err != nil {
 		return nil, err
 	}
-	if w.extendCall {
+	meta, specVersion := w.conn.getVersionedMetadata()
+	extended := w.extendCalls.extends(m.ResourceId, method)
+
+	call, err := types.NewCall(&meta, method)
+	if err != nil {
+		return nil, newValidationError(ReasonInvalidCall, err)
+	}
+	// The payload is encoded according to the argument types of the method
+	call.Args, err = w.calls.encodeArgs(&meta, specVersion, method, p.Metadata, extended)
+	if err != nil {
+		return nil, newValidationError(ReasonInvalidPayload, err)
+	}
+	if extended {
 		eRID, err := codec.Encode(m.ResourceId)
 		if err != nil {
 			return nil, err
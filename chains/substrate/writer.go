@@ -7,42 +7,134 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
-	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
 	"time"
 
-	"github.com/centrifuge/chainbridge-utils/core"
-
-	utils "github.com/ChainSafe/ChainBridge/shared/substrate"
+	"github.com/ChainSafe/ChainBridge/chains/deadletter"
+	"github.com/ChainSafe/ChainBridge/chains/decimals"
+	"github.com/ChainSafe/ChainBridge/chains/payload"
 	"github.com/ChainSafe/log15"
+	"github.com/centrifuge/chainbridge-utils/core"
 	metrics "github.com/centrifuge/chainbridge-utils/metrics/types"
 	"github.com/centrifuge/chainbridge-utils/msg"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
 )
 
 var _ core.Writer = &writer{}
 
-var AcknowledgeProposal utils.Method = utils.BridgePalletName + ".acknowledge_proposal"
+const acknowledgeProposal = "acknowledge_proposal"
+
 var TerminatedError = errors.New("terminated")
 
+// outcome is the final state of a message handled by the writer
+type outcome string
+
+const (
+	acknowledged outcome = "acknowledged" // A vote for the proposal was submitted
+	rejected     outcome = "rejected"     // The message is invalid, a vote against the proposal was submitted
+	skipped      outcome = "skipped"      // No vote was required, eg. the proposal is complete
+	failed       outcome = "failed"       // The message could not be resolved and was dead-lettered
+	terminated   outcome = "terminated"   // The relayer shut down before the message was resolved
+)
+
 type writer struct {
-	conn       *Connection
-	log        log15.Logger
-	sysErr     chan<- error
-	metrics    *metrics.ChainMetrics
-	extendCall bool // Extend extrinsic calls to substrate with ResourceID.Used for backward compatibility with example pallet.
+	conn        *Connection
+	log         log15.Logger
+	sysErr      chan<- error
+	metrics     *metrics.ChainMetrics
+	extendCalls extendedCalls     // Extend extrinsic calls to substrate with ResourceID. Used for backward compatibility with example pallet.
+	deadLetters deadletter.Storer // Persists messages that failed to resolve
+	interrupted *interruptedStore // Persists messages interrupted by a shutdown, nil if unset
+	batcher     *batcher          // Collects votes into batch calls, nil if batching is disabled
+	resources   *resourceCache    // Caches the methods of resolved resource IDs
+	calls       *callRegistries   // Decoders of the call arguments per runtime version
+	decimals    *decimals.Table   // Converts fungible amounts to the decimals of this chain
+	network     *uint16           // SS58 network prefix of the chain, SS58 recipients must match it if set
 }
 
-func NewWriter(conn *Connection, log log15.Logger, sysErr chan<- error, m *metrics.ChainMetrics, extendCall bool) *writer {
+func NewWriter(conn *Connection, log log15.Logger, sysErr chan<- error, m *metrics.ChainMetrics, extendCalls extendedCalls, dl deadletter.Storer) *writer {
 	return &writer{
-		conn:       conn,
-		log:        log,
-		sysErr:     sysErr,
-		metrics:    m,
-		extendCall: extendCall,
+		conn:        conn,
+		log:         log,
+		sysErr:      sysErr,
+		metrics:     m,
+		extendCalls: extendCalls,
+		deadLetters: dl,
+		resources:   newResourceCache(DefaultResourceCacheTTL),
+		calls:       newCallRegistries(),
+		decimals:    decimals.NewTable(),
+	}
+}
+
+// start begins collecting votes if batching is enabled, and resolves the messages interrupted by the last shutdown
+func (w *writer) start() {
+	if w.batcher != nil {
+		go w.batcher.run()
+	}
+	if w.interrupted == nil {
+		return
+	}
+	msgs, err := w.interrupted.pending()
+	if err != nil {
+		w.log.Error("Failed to load interrupted messages", "err", err)
+		return
 	}
+	go func() {
+		for _, m := range msgs {
+			w.log.Info("Resolving message interrupted by shutdown", "src", m.Source, "nonce", m.DepositNonce)
+			// Only forgotten once it has an outcome, a message interrupted again stays recorded
+			if w.resolveMessage(m) == terminated {
+				return
+			}
+			if err := w.interrupted.remove(m); err != nil {
+				w.log.Error("Failed to remove interrupted message", "src", m.Source, "nonce", m.DepositNonce, "err", err)
+			}
+		}
+	}()
 }
 
+// ResolveMessage votes on the proposal for the message. Every message ends up acknowledged, rejected, skipped or
+// failed. Rejected and failed messages are written to the dead-letter store. Messages interrupted by a shutdown are
+// resolved again after a restart. Returns false only if the message failed.
 func (w *writer) ResolveMessage(m msg.Message) bool {
+	return w.resolveMessage(m) != failed
+}
+
+// resolveMessage resolves the message and handles its outcome, as described for ResolveMessage
+func (w *writer) resolveMessage(m msg.Message) outcome {
+	res, reason, err := w.resolve(m)
+
+	switch res {
+	case acknowledged:
+		w.log.Info("Proposal acknowledged", "src", m.Source, "nonce", m.DepositNonce, "resource", m.ResourceId.Hex())
+	case rejected:
+		w.log.Warn("Proposal rejected", "reason", reason, "src", m.Source, "nonce", m.DepositNonce, "resource", m.ResourceId.Hex(), "err", err)
+		if dlErr := w.deadLetters.StoreMessage(m, err); dlErr != nil {
+			w.log.Error("Failed to write message to dead-letter store", "src", m.Source, "nonce", m.DepositNonce, "err", dlErr)
+		}
+	case skipped:
+		w.log.Info("Proposal skipped", "reason", reason, "src", m.Source, "nonce", m.DepositNonce, "resource", m.ResourceId.Hex())
+	case failed:
+		w.log.Error("Failed to resolve message", "src", m.Source, "nonce", m.DepositNonce, "resource", m.ResourceId.Hex(), "err", err)
+		if dlErr := w.deadLetters.StoreMessage(m, err); dlErr != nil {
+			w.log.Error("Failed to write message to dead-letter store", "src", m.Source, "nonce", m.DepositNonce, "err", dlErr)
+		}
+	case terminated:
+		w.log.Info("Shutdown before message was resolved", "src", m.Source, "nonce", m.DepositNonce, "resource", m.ResourceId.Hex())
+		if w.interrupted == nil {
+			break
+		}
+		if err := w.interrupted.add(m); err != nil {
+			w.log.Error("Failed to record interrupted message", "src", m.Source, "nonce", m.DepositNonce, "err", err)
+		}
+	}
+	return res
+}
+
+// resolve constructs the proposal for the message and submits a vote for it if required. Messages that fail validation
+// are voted against, unless their resource is unknown to the chain. A reason is returned for rejected and skipped
+// messages, and an error for rejected and failed ones.
+func (w *writer) resolve(m msg.Message) (outcome, string, error) {
 	var prop *proposal
 	var err error
 
@@ -52,18 +144,43 @@ func (w *writer) ResolveMessage(m msg.Message) bool {
 		prop, err = w.createFungibleProposal(m)
 	case msg.NonFungibleTransfer:
 		prop, err = w.createNonFungibleProposal(m)
+	case payload.SemiFungibleTransfer:
+		prop, err = w.createSemiFungibleProposal(m)
 	case msg.GenericTransfer:
 		prop, err = w.createGenericProposal(m)
 	default:
-		w.sysErr <- fmt.Errorf("unrecognized message type received (chain=%d, name=%s)", m.Destination, w.conn.name)
-		return false
+		return failed, "", fmt.Errorf("unrecognized message type received (chain=%d, name=%s)", m.Destination, w.conn.name)
 	}
 
+	var invalid *ValidationError
+	if errors.As(err, &invalid) && !invalid.rejectable() {
+		return failed, "", invalid
+	} else if invalid != nil {
+		return w.reject(m, invalid)
+	} else if err != nil {
+		return failed, "", fmt.Errorf("failed to construct proposal (chain=%d, name=%s) Error: %w", m.Destination, w.conn.name, err)
+	}
+
+	return w.vote(prop, acknowledgeProposal, acknowledged)
+}
+
+// reject votes against the proposal of an invalid message
+func (w *writer) reject(m msg.Message, invalid *ValidationError) (outcome, string, error) {
+	prop, err := w.createRejectionProposal(m, invalid)
 	if err != nil {
-		w.sysErr <- fmt.Errorf("failed to construct proposal (chain=%d, name=%s) Error: %w", m.Destination, w.conn.name, err)
-		return false
+		return failed, "", fmt.Errorf("failed to construct rejection (chain=%d, name=%s) Error: %w", m.Destination, w.conn.name, err)
 	}
 
+	res, reason, err := w.vote(prop, rejectProposal, rejected)
+	if res != rejected {
+		return res, reason, err
+	}
+	return rejected, invalid.Reason, invalid
+}
+
+// vote submits a vote for the proposal with the given call of the bridge pallet, unless the proposal has completed
+// or this relayer has already voted. The result is returned as outcome if the vote was submitted.
+func (w *writer) vote(prop *proposal, call string, res outcome) (outcome, string, error) {
 	for i := 0; i < BlockRetryLimit; i++ {
 		// Ensure we only submit a vote if the proposal hasn't completed
 		valid, reason, err := w.proposalValid(prop)
@@ -74,38 +191,66 @@ func (w *writer) ResolveMessage(m msg.Message) bool {
 		}
 
 		// If active submit call, otherwise skip it. Retry on failure.
-		if valid {
-			w.log.Info("Acknowledging proposal on chain", "nonce", prop.depositNonce, "source", prop.sourceId, "resource", fmt.Sprintf("%x", prop.resourceId), "method", prop.method)
-
-			err = w.conn.SubmitTx(AcknowledgeProposal, prop.depositNonce, prop.sourceId, prop.resourceId, prop.call)
-			if err != nil && err.Error() == TerminatedError.Error() {
-				return false
-			} else if err != nil {
-				w.log.Error("Failed to execute extrinsic", "err", err)
-				time.Sleep(BlockRetryInterval)
-				continue
-			}
-			if w.metrics != nil {
-				w.metrics.VotesSubmitted.Inc()
-			}
-			return true
-		} else {
-			w.log.Info("Ignoring proposal", "reason", reason, "nonce", prop.depositNonce, "source", prop.sourceId, "resource", prop.resourceId)
-			return true
+		if !valid {
+			return skipped, reason, nil
 		}
+
+		w.log.Info("Voting on proposal on chain", "call", call, "nonce", prop.depositNonce, "source", prop.sourceId, "resource", fmt.Sprintf("%x", prop.resourceId), "method", prop.method)
+
+		err = w.submitVote(call, prop)
+		var failedErr *ExtrinsicFailedError
+		if err != nil && err.Error() == TerminatedError.Error() {
+			return terminated, "", err
+		} else if errors.As(err, &failedErr) && !failedErr.Retriable() {
+			return failed, "", err
+		} else if err != nil {
+			w.log.Error("Failed to execute extrinsic", "err", err)
+			time.Sleep(BlockRetryInterval)
+			continue
+		}
+		if w.metrics != nil {
+			w.metrics.VotesSubmitted.Inc()
+		}
+		return res, "", nil
+	}
+	return failed, "", fmt.Errorf("%s failed after %d attempts", call, BlockRetryLimit)
+}
+
+// submitVote submits the call for the proposal, as part of a batch if batching is enabled
+func (w *writer) submitVote(call string, prop *proposal) error {
+	method := w.conn.names.method(call)
+	if w.batcher != nil {
+		return w.batcher.submit(method, prop.depositNonce, prop.sourceId, prop.resourceId, prop.call)
 	}
-	return true
+	return w.conn.SubmitTx(method, prop.depositNonce, prop.sourceId, prop.resourceId, prop.call)
 }
 
 func (w *writer) resolveResourceId(id [32]byte) (string, error) {
+	// Proposal calls are encoded with the metadata, cached entries only apply to its runtime
+	_, specVersion := w.conn.getVersionedMetadata()
+	if method, ok := w.resources.get(id, specVersion); ok {
+		return method, nil
+	}
+
+	// Query errors are retried, only a resource missing on chain is a validation error
 	var res []byte
-	exists, err := w.conn.queryStorage(utils.BridgeStoragePrefix, "Resources", id[:], nil, &res)
+	var exists bool
+	var err error
+	for i := 0; i < BlockRetryLimit; i++ {
+		exists, err = w.conn.queryStorage(w.conn.names.storagePrefix, "Resources", id[:], nil, &res)
+		if err == nil {
+			break
+		}
+		w.log.Error("Failed to query resource", "resource", fmt.Sprintf("%x", id), "err", err)
+		time.Sleep(BlockRetryInterval)
+	}
 	if err != nil {
-		return "", err
+		return "", fmt.Errorf("resource query failed after %d attempts: %w", BlockRetryLimit, err)
 	}
 	if !exists {
-		return "", fmt.Errorf("resource %x not found on chain", id)
+		return "", newValidationError(ReasonUnknownResource, fmt.Errorf("resource %x not found on chain", id))
 	}
+	w.resources.set(id, string(res), specVersion)
 	return string(res), nil
 }
 
@@ -121,7 +266,7 @@ func (w *writer) proposalValid(prop *proposal) (bool, string, error) {
 	if err != nil {
 		return false, "", err
 	}
-	exists, err := w.conn.queryStorage(utils.BridgeStoragePrefix, "Votes", srcId, propBz, &voteRes)
+	exists, err := w.conn.queryStorage(w.conn.names.storagePrefix, "Votes", srcId, propBz, &voteRes)
 	if err != nil {
 		return false, "", err
 	}
@@ -129,12 +274,12 @@ func (w *writer) proposalValid(prop *proposal) (bool, string, error) {
 	if !exists {
 		return true, "", nil
 	} else if voteRes.Status.IsActive {
-		accountID, err := types.NewAccountID(w.conn.key.PublicKey)
+		accountID, err := w.conn.accountID()
 		if err != nil {
 			return false, "", err
 		}
-		if containsVote(voteRes.VotesFor, *accountID) ||
-			containsVote(voteRes.VotesAgainst, *accountID) {
+		if containsVote(voteRes.VotesFor, accountID) ||
+			containsVote(voteRes.VotesAgainst, accountID) {
 			return false, "already voted", nil
 		} else {
 			return true, "", nil
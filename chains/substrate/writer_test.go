@@ -320,3 +320,28 @@ func TestWriter_ResolveMessage_Duplicate(t *testing.T) {
 	}
 
 }
+
+type recordingStore struct {
+	msgs []message.Message
+	errs []error
+}
+
+func (s *recordingStore) StoreMessage(m message.Message, err error) error {
+	s.msgs = append(s.msgs, m)
+	s.errs = append(s.errs, err)
+	return nil
+}
+
+func TestWriter_ResolveMessage_DeadLetter(t *testing.T) {
+	dl := &recordingStore{}
+	conn := NewConnection(TestEndpoint, "Alice", AliceKey, AliceTestLogger, make(chan int), make(chan error))
+	w := NewWriter(conn, AliceTestLogger, make(chan error), nil, extendedCalls{}, dl)
+
+	m := message.Message{Source: ForeignChain, Destination: ThisChain, Type: "Unknown", DepositNonce: 5}
+	if w.ResolveMessage(m) {
+		t.Fatal("expected unknown message type to fail")
+	}
+	if len(dl.msgs) != 1 || dl.msgs[0].DepositNonce != 5 || dl.errs[0] == nil {
+		t.Fatalf("expected message in dead-letter store, got: %#v", dl.msgs)
+	}
+}
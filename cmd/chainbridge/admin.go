@@ -0,0 +1,42 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package main
+
+import (
+	"mime"
+	"net/http"
+
+	"github.com/ChainSafe/ChainBridge/chains/approval"
+	"github.com/ChainSafe/ChainBridge/chains/breaker"
+)
+
+// newAdminHandler serves the approval queues and circuit breakers of the relayer
+func newAdminHandler(approvals *approval.Registry, breakers *breaker.Registry) http.Handler {
+	approvalServer := approval.NewServer(approvals)
+	breakerServer := breaker.NewServer(breakers)
+	mux := http.NewServeMux()
+	mux.Handle("/approvals", approvalServer)
+	mux.Handle("/approvals/", approvalServer)
+	mux.Handle("/breaker", breakerServer)
+	mux.Handle("/breaker/", breakerServer)
+	return refuseBrowserRequests(mux)
+}
+
+// refuseBrowserRequests refuses requests with an Origin header, and POST requests without a JSON body. The admin
+// endpoint only listens on localhost, but a web page opened on the host could still send such requests to it.
+func refuseBrowserRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Origin") != "" {
+			http.Error(w, "cross-origin requests are not allowed", http.StatusForbidden)
+			return
+		}
+		if req.Method == http.MethodPost {
+			if mediaType, _, err := mime.ParseMediaType(req.Header.Get("Content-Type")); err != nil || mediaType != "application/json" {
+				http.Error(w, "content type must be application/json", http.StatusUnsupportedMediaType)
+				return
+			}
+		}
+		next.ServeHTTP(w, req)
+	})
+}
@@ -0,0 +1,92 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ChainSafe/ChainBridge/chains/approval"
+	"github.com/ChainSafe/ChainBridge/chains/breaker"
+	"github.com/ChainSafe/log15"
+	"github.com/centrifuge/chainbridge-utils/msg"
+)
+
+type mockWriter struct{}
+
+func (w *mockWriter) ResolveMessage(_ msg.Message) bool { return true }
+
+func TestAdminHandler_RejectsBrowserRequests(t *testing.T) {
+	rId := msg.ResourceId{31: 1}
+	large := msg.NewFungibleTransfer(1, 2, 1, big.NewInt(5000), rId, []byte{0xab})
+
+	q, err := approval.NewQueue(&mockWriter{}, approval.Thresholds{rId: big.NewInt(1000)}, t.TempDir(), 2, "relayer", log15.Root())
+	if err != nil {
+		t.Fatal(err)
+	}
+	q.ResolveMessage(large)
+	approvals := approval.NewRegistry()
+	approvals.Register(q)
+
+	limits, err := breaker.ParseLimits(fmt.Sprintf("0x%x:1000:1h", rId))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := breaker.NewBreaker(&mockWriter{}, limits, nil, t.TempDir(), 2, "relayer", log15.Root())
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.ResolveMessage(large)
+	breakers := breaker.NewRegistry()
+	breakers.Register(b)
+
+	srv := httptest.NewServer(newAdminHandler(approvals, breakers))
+	defer srv.Close()
+
+	paths := []string{
+		fmt.Sprintf("/approvals/2/%s/approve", approval.ItemID(large)),
+		"/breaker/2/1/resume",
+	}
+	testCases := []struct {
+		name        string
+		contentType string
+		origin      string
+		expected    int
+	}{
+		{name: "form post", contentType: "text/plain", expected: http.StatusUnsupportedMediaType},
+		{name: "cross-origin", contentType: "application/json", origin: "http://example.com", expected: http.StatusForbidden},
+	}
+	for _, path := range paths {
+		for _, tc := range testCases {
+			t.Run(tc.name+" "+path, func(t *testing.T) {
+				req, err := http.NewRequest(http.MethodPost, srv.URL+path, strings.NewReader(`{"operator": "mallory"}`))
+				if err != nil {
+					t.Fatal(err)
+				}
+				req.Header.Set("Content-Type", tc.contentType)
+				if tc.origin != "" {
+					req.Header.Set("Origin", tc.origin)
+				}
+				res, err := http.DefaultClient.Do(req)
+				if err != nil {
+					t.Fatal(err)
+				}
+				res.Body.Close()
+				if res.StatusCode != tc.expected {
+					t.Fatalf("Got: %d Expected: %d", res.StatusCode, tc.expected)
+				}
+			})
+		}
+	}
+	if pending := q.Pending(); len(pending) != 1 {
+		t.Fatalf("Got: %v Expected the transfer to remain parked", pending)
+	}
+	if halted := b.Halted(); len(halted) != 1 {
+		t.Fatalf("Got: %v Expected the route to remain halted", halted)
+	}
+}
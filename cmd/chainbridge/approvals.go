@@ -0,0 +1,86 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/ChainSafe/ChainBridge/chains/approval"
+	"github.com/ChainSafe/ChainBridge/config"
+	log "github.com/ChainSafe/log15"
+	"github.com/centrifuge/chainbridge-utils/msg"
+	"github.com/urfave/cli/v2"
+)
+
+// adminURL returns the url of the admin endpoint of the relayer running on this host
+func adminURL(ctx *cli.Context) string {
+	return fmt.Sprintf("http://127.0.0.1:%d", ctx.Int(config.AdminPortFlag.Name))
+}
+
+// adminClient returns a client of the approval queues of the relayer running on this host
+func adminClient(ctx *cli.Context) *approval.Client {
+	return approval.NewClient(adminURL(ctx))
+}
+
+// handleApprovalsListCmd prints the transfers waiting for approval
+func handleApprovalsListCmd(ctx *cli.Context) error {
+	items, err := adminClient(ctx).Pending()
+	if err != nil {
+		return fmt.Errorf("failed to list parked transfers: %w", err)
+	}
+
+	if len(items) == 0 {
+		fmt.Println("No transfers are waiting for approval")
+		return nil
+	}
+	for _, item := range items {
+		fmt.Printf("chain: %d id: %s source: %d nonce: %d resource: %s amount: %s parked: %s\n",
+			item.Chain, item.ID, item.Message.Source, item.Message.DepositNonce, item.Message.ResourceId,
+			item.Amount, item.ParkedAt.Format("2006-01-02 15:04:05"))
+	}
+	return nil
+}
+
+// handleApprovalsApproveCmd approves a parked transfer
+func handleApprovalsApproveCmd(ctx *cli.Context) error {
+	chain, id, operator, err := parseDecision(ctx)
+	if err != nil {
+		return err
+	}
+	if err = adminClient(ctx).Approve(chain, id, operator); err != nil {
+		return fmt.Errorf("failed to approve transfer: %w", err)
+	}
+	log.Info("Transfer approved", "chain", chain, "id", id)
+	return nil
+}
+
+// handleApprovalsRejectCmd rejects a parked transfer
+func handleApprovalsRejectCmd(ctx *cli.Context) error {
+	chain, id, operator, err := parseDecision(ctx)
+	if err != nil {
+		return err
+	}
+	if err = adminClient(ctx).Reject(chain, id, operator); err != nil {
+		return fmt.Errorf("failed to reject transfer: %w", err)
+	}
+	log.Info("Transfer rejected", "chain", chain, "id", id)
+	return nil
+}
+
+// parseDecision reads the chain ID and transfer ID arguments, and the operator
+func parseDecision(ctx *cli.Context) (msg.ChainId, string, string, error) {
+	if ctx.NArg() != 2 {
+		return 0, "", "", fmt.Errorf("expected <chainId> <id> arguments")
+	}
+	chain, err := strconv.ParseUint(ctx.Args().Get(0), 10, 8)
+	if err != nil {
+		return 0, "", "", fmt.Errorf("invalid chain ID %s", ctx.Args().Get(0))
+	}
+	operator := ctx.String(config.OperatorFlag.Name)
+	if operator == "" {
+		return 0, "", "", fmt.Errorf("--%s is required", config.OperatorFlag.Name)
+	}
+	return msg.ChainId(chain), ctx.Args().Get(1), operator, nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2020 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/ChainSafe/ChainBridge/chains/breaker"
+	"github.com/ChainSafe/ChainBridge/config"
+	log "github.com/ChainSafe/log15"
+	"github.com/centrifuge/chainbridge-utils/msg"
+	"github.com/urfave/cli/v2"
+)
+
+// handleBreakerListCmd prints the halted routes
+func handleBreakerListCmd(ctx *cli.Context) error {
+	client := breaker.NewClient(adminURL(ctx))
+	halted, err := client.Halted()
+	if err != nil {
+		return fmt.Errorf("failed to list halted routes: %w", err)
+	}
+
+	if len(halted) == 0 {
+		fmt.Println("No routes are halted")
+		return nil
+	}
+	for _, halt := range halted {
+		fmt.Printf("chain: %d source: %d tripped: %s held: %d reason: %s\n",
+			halt.Chain, halt.Source, halt.TrippedAt.Format("2006-01-02 15:04:05"), len(halt.Held), halt.Reason)
+		for _, m := range halt.Held {
+			fmt.Printf("\tnonce: %d resource: %s type: %s\n", m.DepositNonce, m.ResourceId, m.Type)
+		}
+	}
+	return nil
+}
+
+// handleBreakerResumeCmd resumes a halted route
+func handleBreakerResumeCmd(ctx *cli.Context) error {
+	if ctx.NArg() != 2 {
+		return fmt.Errorf("expected <chainId> <sourceChainId> arguments")
+	}
+	chain, err := strconv.ParseUint(ctx.Args().Get(0), 10, 8)
+	if err != nil {
+		return fmt.Errorf("invalid chain ID %s", ctx.Args().Get(0))
+	}
+	source, err := strconv.ParseUint(ctx.Args().Get(1), 10, 8)
+	if err != nil {
+		return fmt.Errorf("invalid source chain ID %s", ctx.Args().Get(1))
+	}
+	operator := ctx.String(config.OperatorFlag.Name)
+	if operator == "" {
+		return fmt.Errorf("--%s is required", config.OperatorFlag.Name)
+	}
+
+	client := breaker.NewClient(adminURL(ctx))
+	drop := ctx.Bool(config.DropHeldFlag.Name)
+	held, err := client.Resume(msg.ChainId(chain), msg.ChainId(source), operator, drop)
+	if err != nil {
+		return fmt.Errorf("failed to resume route: %w", err)
+	}
+	log.Info("Route resumed", "chain", chain, "source", source, "held", held, "dropped", drop)
+	return nil
+}
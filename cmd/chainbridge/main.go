@@ -15,6 +15,8 @@ import (
 
 	"strconv"
 
+	"github.com/ChainSafe/ChainBridge/chains/approval"
+	"github.com/ChainSafe/ChainBridge/chains/breaker"
 	"github.com/ChainSafe/ChainBridge/chains/ethereum"
 	"github.com/ChainSafe/ChainBridge/chains/substrate"
 	"github.com/ChainSafe/ChainBridge/config"
@@ -38,6 +40,8 @@ var cliFlags = []cli.Flag{
 	config.LatestBlockFlag,
 	config.MetricsFlag,
 	config.MetricsPort,
+	config.AdminFlag,
+	config.AdminPortFlag,
 }
 
 var generateFlags = []cli.Flag{
@@ -97,6 +101,68 @@ var accountCommand = cli.Command{
 	},
 }
 
+var approvalsCommand = cli.Command{
+	Name:  "approvals",
+	Usage: "manage transfers waiting for approval",
+	Description: "The approvals command acts on the transfers parked by a running relayer started with --admin.\n" +
+		"\tTo list parked transfers: chainbridge approvals list\n" +
+		"\tTo approve a transfer: chainbridge approvals approve --operator name <chainId> <id>\n" +
+		"\tTo reject a transfer: chainbridge approvals reject --operator name <chainId> <id>",
+	Subcommands: []*cli.Command{
+		{
+			Action:      handleApprovalsListCmd,
+			Name:        "list",
+			Usage:       "list parked transfers",
+			Flags:       []cli.Flag{config.AdminPortFlag},
+			Description: "The list subcommand lists the transfers waiting for approval on all chains.\n",
+		},
+		{
+			Action:    handleApprovalsApproveCmd,
+			Name:      "approve",
+			Usage:     "approve a parked transfer",
+			ArgsUsage: "<chainId> <id>",
+			Flags:     []cli.Flag{config.AdminPortFlag, config.OperatorFlag},
+			Description: "The approve subcommand passes a parked transfer on to the writer of its destination chain.\n" +
+				"\tThe operator is recorded in the audit log.",
+		},
+		{
+			Action:    handleApprovalsRejectCmd,
+			Name:      "reject",
+			Usage:     "reject a parked transfer",
+			ArgsUsage: "<chainId> <id>",
+			Flags:     []cli.Flag{config.AdminPortFlag, config.OperatorFlag},
+			Description: "The reject subcommand drops a parked transfer.\n" +
+				"\tThe operator is recorded in the audit log.",
+		},
+	},
+}
+
+var breakerCommand = cli.Command{
+	Name:  "breaker",
+	Usage: "manage routes halted by the circuit breaker",
+	Description: "The breaker command acts on the routes halted by a running relayer started with --admin.\n" +
+		"\tTo list halted routes: chainbridge breaker list\n" +
+		"\tTo resume a route: chainbridge breaker resume --operator name <chainId> <sourceChainId>",
+	Subcommands: []*cli.Command{
+		{
+			Action:      handleBreakerListCmd,
+			Name:        "list",
+			Usage:       "list halted routes",
+			Flags:       []cli.Flag{config.AdminPortFlag},
+			Description: "The list subcommand lists the halted routes to all chains, and the messages held on them.\n",
+		},
+		{
+			Action:    handleBreakerResumeCmd,
+			Name:      "resume",
+			Usage:     "resume a halted route",
+			ArgsUsage: "<chainId> <sourceChainId>",
+			Flags:     []cli.Flag{config.AdminPortFlag, config.OperatorFlag, config.DropHeldFlag},
+			Description: "The resume subcommand lets a halted route relay again, and resets its volume.\n" +
+				"\tThe held messages are relayed, unless --drop is set.",
+		},
+	},
+}
+
 var (
 	Version = "0.0.1"
 )
@@ -112,6 +178,8 @@ func init() {
 	app.EnableBashCompletion = true
 	app.Commands = []*cli.Command{
 		&accountCommand,
+		&approvalsCommand,
+		&breakerCommand,
 	}
 
 	app.Flags = append(app.Flags, cliFlags...)
@@ -233,6 +301,21 @@ func run(ctx *cli.Context) error {
 		}()
 	}
 
+	// Start admin server, only reachable from this host
+	if ctx.Bool(config.AdminFlag.Name) {
+		port := ctx.Int(config.AdminPortFlag.Name)
+		handler := newAdminHandler(approval.DefaultRegistry, breaker.DefaultRegistry)
+
+		go func() {
+			err := http.ListenAndServe(fmt.Sprintf("127.0.0.1:%d", port), handler)
+			if errors.Is(err, http.ErrServerClosed) {
+				log.Info("Admin server is shutting down", err)
+			} else {
+				log.Error("Error serving admin endpoint", "err", err)
+			}
+		}()
+	}
+
 	c.Start()
 
 	return nil
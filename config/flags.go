@@ -62,6 +62,37 @@ var (
 	}
 )
 
+// Admin flags
+var (
+	AdminFlag = &cli.BoolFlag{
+		Name:  "admin",
+		Usage: "Enables the admin endpoint on localhost, used to approve parked transfers",
+	}
+
+	AdminPortFlag = &cli.IntFlag{
+		Name:  "adminPort",
+		Usage: "Port to serve the admin endpoint on",
+		Value: 8002,
+	}
+)
+
+// Approvals subcommand flags
+var (
+	OperatorFlag = &cli.StringFlag{
+		Name:    "operator",
+		Usage:   "Name of the operator recorded in the audit log",
+		EnvVars: []string{"USER"},
+	}
+)
+
+// Breaker subcommand flags
+var (
+	DropHeldFlag = &cli.BoolFlag{
+		Name:  "drop",
+		Usage: "Drops the messages held while the route was halted instead of relaying them",
+	}
+)
+
 // Generate subcommand flags
 var (
 	PasswordFlag = &cli.StringFlag{
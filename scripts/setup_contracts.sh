@@ -6,6 +6,10 @@ CONTRACTS_REPO="https://github.com/ChainSafe/chainbridge-solidity"
 CONTRACTS_TAG="v1.0.0"
 CONTRACTS_DIR="./solidity"
 DEST_DIR="./bindings"
+# ABI of the version 2 Bridge contract, see contractVersion
+BRIDGE_V2_ABI="./scripts/abi/BridgeV2.abi"
+# ABI of the ERC1155 handler, which is not part of the contracts at CONTRACTS_TAG
+ERC1155_HANDLER_ABI="./scripts/abi/ERC1155Handler.abi"
 
 set -eux
 
@@ -22,6 +26,12 @@ case $TARGET in
 
     mkdir $DEST_DIR
     cp -r $CONTRACTS_DIR/build/bindings/go/* $DEST_DIR
+
+    mkdir $DEST_DIR/BridgeV2
+    abigen --abi $BRIDGE_V2_ABI --pkg BridgeV2 --type BridgeV2 --out $DEST_DIR/BridgeV2/BridgeV2.go
+
+    mkdir $DEST_DIR/ERC1155Handler
+    abigen --abi $ERC1155_HANDLER_ABI --pkg ERC1155Handler --type ERC1155Handler --out $DEST_DIR/ERC1155Handler/ERC1155Handler.go
 		;;
 
 	"cli-only")